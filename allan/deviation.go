@@ -0,0 +1,146 @@
+package allan
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point of an Allan deviation curve.
+type Point struct {
+	// Cluster duration, in seconds.
+	Tau float64 `json:"tau_s"`
+	// Allan deviation, in the unit of the samples.
+	Deviation float64 `json:"deviation"`
+	// Number of independent clusters of duration Tau in the recording, the
+	// relative error of Deviation is about 1/√(2(Clusters-1)).
+	Clusters int `json:"clusters"`
+}
+
+// Deviation computes the overlapping Allan deviation of samples taken at
+// sampleRate (Hz) for cluster sizes spaced logarithmically, pointsPerDecade
+// per decade, from one sample to a third of the recording.
+func Deviation(samples []float64, sampleRate float64, pointsPerDecade int) ([]Point, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %v", sampleRate)
+	}
+	if pointsPerDecade < 1 {
+		return nil, fmt.Errorf("points per decade must be at least 1, got %d", pointsPerDecade)
+	}
+	n := len(samples)
+	maxClusterSize := n / 3
+	if maxClusterSize < 1 {
+		return nil, fmt.Errorf("at least 3 samples are needed, got %d", n)
+	}
+
+	// Integrate the samples (angle for the gyro, velocity for the accelerometer).
+	period := 1 / sampleRate
+	theta := make([]float64, n+1)
+	for i, x := range samples {
+		theta[i+1] = theta[i] + x*period
+	}
+
+	var curve []Point
+	for _, m := range clusterSizes(maxClusterSize, pointsPerDecade) {
+		tau := float64(m) * period
+		sum := 0.0
+		terms := n + 1 - 2*m
+		for k := 0; k < terms; k++ {
+			d := theta[k+2*m] - 2*theta[k+m] + theta[k]
+			sum += d * d
+		}
+		curve = append(curve, Point{
+			Tau:       tau,
+			Deviation: math.Sqrt(sum / (2 * tau * tau * float64(terms))),
+			Clusters:  n / m,
+		})
+	}
+	return curve, nil
+}
+
+func clusterSizes(max int, pointsPerDecade int) []int {
+	var sizes []int
+	for i := 0; ; i++ {
+		m := int(math.Round(math.Pow(10, float64(i)/float64(pointsPerDecade))))
+		if m > max {
+			return sizes
+		}
+		if len(sizes) == 0 || m > sizes[len(sizes)-1] {
+			sizes = append(sizes, m)
+		}
+	}
+}
+
+// Coefficients are the noise terms read from an Allan deviation curve, in the
+// unit of the samples (dps for the gyro, g for the accelerometer).
+type Coefficients struct {
+	// White noise density (N), in unit/√Hz: the -1/2 slope line at τ = 1s.
+	// Angle random walk for the gyro (×60 for °/√h), velocity random walk for
+	// the accelerometer.
+	RandomWalk float64 `json:"random_walk"`
+	// Bias instability (B), in unit: the minimum of the curve divided by 0.664.
+	BiasInstability float64 `json:"bias_instability"`
+	// Cluster duration of the minimum, in seconds.
+	BiasInstabilityTau float64 `json:"bias_instability_tau_s"`
+	// Rate random walk (K), in unit·√Hz: the +1/2 slope line at τ = 3s. Nil
+	// when the recording is too short for the curve to rise again.
+	RateRandomWalk *float64 `json:"rate_random_walk,omitempty"`
+}
+
+// Slopes (log-log) further than this from the one of a noise term are not
+// used to read its coefficient.
+const slopeTolerance = 0.2
+
+// ExtractCoefficients reads the noise coefficients from a curve computed by
+// Deviation.
+func ExtractCoefficients(curve []Point) (*Coefficients, error) {
+	if len(curve) < 3 {
+		return nil, fmt.Errorf("at least 3 points are needed, got %d", len(curve))
+	}
+
+	minimum := 0
+	for i, point := range curve {
+		if point.Deviation < curve[minimum].Deviation {
+			minimum = i
+		}
+	}
+	coefficients := &Coefficients{
+		BiasInstability:    curve[minimum].Deviation / math.Sqrt(2*math.Ln2/math.Pi),
+		BiasInstabilityTau: curve[minimum].Tau,
+	}
+
+	randomWalk := closestSlope(curve, 0, minimum, -0.5)
+	if randomWalk < 0 {
+		return nil, fmt.Errorf("no white noise (-1/2 slope) region in the curve")
+	}
+	coefficients.RandomWalk = curve[randomWalk].Deviation * math.Sqrt(curve[randomWalk].Tau)
+
+	if rateRandomWalk := closestSlope(curve, minimum, len(curve), 0.5); rateRandomWalk >= 0 {
+		k := curve[rateRandomWalk].Deviation * math.Sqrt(3/curve[rateRandomWalk].Tau)
+		coefficients.RateRandomWalk = &k
+	}
+	return coefficients, nil
+}
+
+// closestSlope returns the index, in [from, to), of the point whose log-log
+// slope is the closest to slope, or -1 when none is within slopeTolerance.
+func closestSlope(curve []Point, from, to int, slope float64) int {
+	best, bestError := -1, slopeTolerance
+	for i := from; i < to; i++ {
+		previous, next := i-1, i+1
+		if previous < 0 {
+			previous = i
+		}
+		if next >= len(curve) {
+			next = i
+		}
+		if previous == next {
+			continue
+		}
+
+		local := math.Log(curve[next].Deviation/curve[previous].Deviation) / math.Log(curve[next].Tau/curve[previous].Tau)
+		if e := math.Abs(local - slope); e <= bestError {
+			best, bestError = i, e
+		}
+	}
+	return best
+}
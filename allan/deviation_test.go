@@ -0,0 +1,148 @@
+package allan
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleRate = 100
+
+// simulate returns stationary samples with a white noise of density
+// randomWalk (unit/√Hz) and a bias drifting as a random walk of density
+// rateRandomWalk (unit·√Hz).
+func simulate(seed int64, count int, randomWalk, rateRandomWalk float64) []float64 {
+	random := rand.New(rand.NewSource(seed))
+	samples := make([]float64, count)
+	bias := 0.5
+	for i := range samples {
+		samples[i] = bias + randomWalk*math.Sqrt(sampleRate)*random.NormFloat64()
+		bias += rateRandomWalk / math.Sqrt(sampleRate) * random.NormFloat64()
+	}
+	return samples
+}
+
+func Test_Deviation(t *testing.T) {
+	curve, err := Deviation(simulate(1, 100000, 0.01, 0), sampleRate, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.01, curve[0].Tau)
+	assert.Equal(t, 100000, curve[0].Clusters)
+	assert.InDelta(t, 316.23, curve[len(curve)-1].Tau, 0.01)
+	for _, point := range curve {
+		if point.Clusters >= 100 {
+			assert.InEpsilon(t, 0.01/math.Sqrt(point.Tau), point.Deviation, 0.15, "tau %v", point.Tau)
+		}
+	}
+
+	_, err = Deviation([]float64{1, 2}, sampleRate, 10)
+	assert.Error(t, err)
+}
+
+func Test_ExtractCoefficients(t *testing.T) {
+	tests := []struct {
+		name                   string
+		randomWalk             float64
+		rateRandomWalk         float64
+		expectedRateRandomWalk bool
+	}{
+		{"white noise", 0.01, 0, false},
+		{"white noise and drift", 0.01, 0.001, true},
+		{"noisy accelerometer", 0.0002, 0.00005, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			curve, err := Deviation(simulate(2, 100000, test.randomWalk, test.rateRandomWalk), sampleRate, 10)
+			require.NoError(t, err)
+
+			coefficients, err := ExtractCoefficients(curve)
+			require.NoError(t, err)
+
+			assert.InEpsilon(t, test.randomWalk, coefficients.RandomWalk, 0.1)
+			assert.Greater(t, coefficients.BiasInstability, 0.0)
+			if !test.expectedRateRandomWalk {
+				assert.Nil(t, coefficients.RateRandomWalk)
+				return
+			}
+			require.NotNil(t, coefficients.RateRandomWalk)
+			assert.InEpsilon(t, test.rateRandomWalk, *coefficients.RateRandomWalk, 0.35)
+		})
+	}
+}
+
+func Test_Recording(t *testing.T) {
+	gyro := simulate(3, 10000, 0.01, 0)
+	accelerometer := simulate(4, 10000, 0.0002, 0)
+
+	recording := NewRecording()
+	for i := range gyro {
+		recording.Add(&iim42652.Sample{
+			Time:         time.Unix(0, 0).Add(time.Duration(i) * 10 * time.Millisecond),
+			AngularRate:  &iim42652.AngularRate{X: gyro[i], Y: gyro[i], Z: gyro[i]},
+			Acceleration: &iim42652.Acceleration{X: accelerometer[i], Y: accelerometer[i], Z: 1 + accelerometer[i]},
+		})
+	}
+	assert.InDelta(t, sampleRate, recording.SampleRate(), 1e-9)
+
+	report, err := recording.Analyze(5)
+	require.NoError(t, err)
+	assert.Equal(t, 10000, report.Samples)
+	require.Len(t, report.Gyro.Axes, 3)
+	assert.InEpsilon(t, 0.01, report.Gyro.Axes[2].Coefficients.RandomWalk, 0.1)
+	assert.InEpsilon(t, 0.0002, report.Accelerometer.Axes[2].Coefficients.RandomWalk, 0.1)
+
+	_, err = json.Marshal(report)
+	require.NoError(t, err)
+
+	curve := &bytes.Buffer{}
+	require.NoError(t, report.WriteCurveCSV(curve))
+	lines := strings.Split(strings.TrimSpace(curve.String()), "\n")
+	assert.Equal(t, "sensor,unit,axis,tau_s,deviation,clusters", lines[0])
+	assert.Len(t, lines, 1+6*len(report.Gyro.Axes[0].Curve))
+
+	coefficients := &bytes.Buffer{}
+	require.NoError(t, report.WriteCoefficientsCSV(coefficients))
+	lines = strings.Split(strings.TrimSpace(coefficients.String()), "\n")
+	assert.Len(t, lines, 7)
+	assert.True(t, strings.HasPrefix(lines[1], "gyro,dps,x,"))
+}
+
+func Test_RecordingAxisError(t *testing.T) {
+	gyro := simulate(3, 10000, 0.01, 0)
+	// Bias drift only, the curve has no white noise region.
+	drift := simulate(5, 10000, 0, 0.01)
+
+	recording := NewRecording()
+	for i := range gyro {
+		recording.Add(&iim42652.Sample{
+			Time:         time.Unix(0, 0).Add(time.Duration(i) * 10 * time.Millisecond),
+			AngularRate:  &iim42652.AngularRate{X: gyro[i], Y: drift[i], Z: gyro[i]},
+			Acceleration: &iim42652.Acceleration{X: gyro[i], Y: gyro[i], Z: 1 + gyro[i]},
+		})
+	}
+
+	report, err := recording.Analyze(5)
+	require.NoError(t, err)
+	failed := report.Gyro.Axes[1]
+	assert.NotEmpty(t, failed.Curve, "the curve is kept")
+	assert.Nil(t, failed.Coefficients)
+	assert.Contains(t, failed.Error, "no white noise")
+	for _, axis := range []*AxisNoise{report.Gyro.Axes[0], report.Gyro.Axes[2], report.Accelerometer.Axes[0]} {
+		assert.Empty(t, axis.Error)
+		require.NotNil(t, axis.Coefficients)
+	}
+
+	coefficients := &bytes.Buffer{}
+	require.NoError(t, report.WriteCoefficientsCSV(coefficients))
+	lines := strings.Split(strings.TrimSpace(coefficients.String()), "\n")
+	assert.Equal(t, "gyro,dps,y,,,,,no white noise (-1/2 slope) region in the curve", lines[2])
+}
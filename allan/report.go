@@ -0,0 +1,170 @@
+package allan
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+var axisNames = []string{"x", "y", "z"}
+
+// Recording accumulates stationary samples along the IMU axes.
+type Recording struct {
+	start, end    time.Time
+	angularRates  [3][]float64
+	accelerations [3][]float64
+}
+
+func NewRecording() *Recording {
+	return &Recording{}
+}
+
+func (r *Recording) Add(sample *iim42652.Sample) {
+	if r.Len() == 0 {
+		r.start = sample.Time
+	}
+	r.end = sample.Time
+
+	for axis, value := range [3]float64{sample.AngularRate.X, sample.AngularRate.Y, sample.AngularRate.Z} {
+		r.angularRates[axis] = append(r.angularRates[axis], value)
+	}
+	for axis, value := range [3]float64{sample.Acceleration.X, sample.Acceleration.Y, sample.Acceleration.Z} {
+		r.accelerations[axis] = append(r.accelerations[axis], value)
+	}
+}
+
+func (r *Recording) Len() int {
+	return len(r.angularRates[0])
+}
+
+// SampleRate is the average rate, in Hz, the samples were taken at.
+func (r *Recording) SampleRate() float64 {
+	if r.Len() < 2 || !r.end.After(r.start) {
+		return 0
+	}
+	return float64(r.Len()-1) / r.end.Sub(r.start).Seconds()
+}
+
+// AxisNoise holds the curve of an axis and its coefficients, or the reason
+// they could not be extracted from the curve.
+type AxisNoise struct {
+	Axis         string        `json:"axis"`
+	Curve        []Point       `json:"curve"`
+	Coefficients *Coefficients `json:"coefficients"`
+	Error        string        `json:"error,omitempty"`
+}
+
+type SensorNoise struct {
+	Sensor string       `json:"sensor"`
+	Unit   string       `json:"unit"`
+	Axes   []*AxisNoise `json:"axes"`
+}
+
+type Report struct {
+	Start         time.Time    `json:"start"`
+	End           time.Time    `json:"end"`
+	Samples       int          `json:"samples"`
+	SampleRate    float64      `json:"sample_rate_hz"`
+	Gyro          *SensorNoise `json:"gyro"`
+	Accelerometer *SensorNoise `json:"accelerometer"`
+}
+
+// Analyze computes the Allan deviation curves and noise coefficients of every
+// axis. The device must have been stationary for the whole recording. An axis
+// whose coefficients cannot be extracted keeps its curve and reports the error
+// in AxisNoise.Error, the others are not affected.
+func (r *Recording) Analyze(pointsPerDecade int) (*Report, error) {
+	sampleRate := r.SampleRate()
+	if sampleRate == 0 {
+		return nil, fmt.Errorf("recording of %d samples is too short", r.Len())
+	}
+
+	gyro, err := analyzeSensor("gyro", "dps", r.angularRates, sampleRate, pointsPerDecade)
+	if err != nil {
+		return nil, err
+	}
+	accelerometer, err := analyzeSensor("accelerometer", "g", r.accelerations, sampleRate, pointsPerDecade)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		Start:         r.start,
+		End:           r.end,
+		Samples:       r.Len(),
+		SampleRate:    sampleRate,
+		Gyro:          gyro,
+		Accelerometer: accelerometer,
+	}, nil
+}
+
+func analyzeSensor(sensor, unit string, samples [3][]float64, sampleRate float64, pointsPerDecade int) (*SensorNoise, error) {
+	noise := &SensorNoise{Sensor: sensor, Unit: unit}
+	for axis, name := range axisNames {
+		curve, err := Deviation(samples[axis], sampleRate, pointsPerDecade)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s deviation: %w", sensor, name, err)
+		}
+		axisNoise := &AxisNoise{Axis: name, Curve: curve}
+		if axisNoise.Coefficients, err = ExtractCoefficients(curve); err != nil {
+			axisNoise.Error = err.Error()
+		}
+		noise.Axes = append(noise.Axes, axisNoise)
+	}
+	return noise, nil
+}
+
+// WriteCurveCSV writes one row per point of every curve.
+func (r *Report) WriteCurveCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"sensor", "unit", "axis", "tau_s", "deviation", "clusters"}); err != nil {
+		return err
+	}
+	for _, sensor := range []*SensorNoise{r.Gyro, r.Accelerometer} {
+		for _, axis := range sensor.Axes {
+			for _, point := range axis.Curve {
+				if err := writer.Write([]string{sensor.Sensor, sensor.Unit, axis.Axis, formatFloat(point.Tau), formatFloat(point.Deviation), strconv.Itoa(point.Clusters)}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCoefficientsCSV writes one row of coefficients per axis. The rate
+// random walk is empty when it could not be extracted, all coefficients are
+// when the axis has an error.
+func (r *Report) WriteCoefficientsCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"sensor", "unit", "axis", "random_walk", "bias_instability", "bias_instability_tau_s", "rate_random_walk", "error"}); err != nil {
+		return err
+	}
+	for _, sensor := range []*SensorNoise{r.Gyro, r.Accelerometer} {
+		for _, axis := range sensor.Axes {
+			row := []string{sensor.Sensor, sensor.Unit, axis.Axis, "", "", "", "", axis.Error}
+			if coefficients := axis.Coefficients; coefficients != nil {
+				row[3] = formatFloat(coefficients.RandomWalk)
+				row[4] = formatFloat(coefficients.BiasInstability)
+				row[5] = formatFloat(coefficients.BiasInstabilityTau)
+				if coefficients.RateRandomWalk != nil {
+					row[6] = formatFloat(*coefficients.RateRandomWalk)
+				}
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', 6, 64)
+}
@@ -18,72 +18,99 @@ The flags are:
 		Clears existing calibration data from the sensor set by the sensor flag.
 	--verify-calibration
 		Verify that measured values make sense.
+	--output
+		Format of the verification report, 'text' or 'json'. Default is 'text'
+	--gyro-bias-tolerance float
+		Maximum residual gyro bias per axis in dps.
+	--acceleration-bias-tolerance float
+		Maximum residual accelerometer bias per axis in g.
+	--expected-gravity float
+		Magnitude in g measured by the accelerometer at rest once calibrated.
+		Default is 1 since calibration keeps gravity.
+	--gravity-error-tolerance float
+		Maximum difference in g between the measured and expected gravity.
 
 imucalibrator takes a sample of imu sensor data, averages it, then programs the imu
 user register to use the calculated average as a bias.
+
+The verification measures the residual bias, noise density and gravity error of
+the sensor and compares them to the tolerances. The process exits with 1 when
+verification fails.
 */
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
 	"github.com/streamingfast/imu-controller/device/iim42652"
 )
 
+var defaultTolerances = iim42652.DefaultVerificationTolerances()
+
 var (
-	sensor            = flag.String("sensor", "", "The sensor to calibrate or clear. Required. Values: gyro,accelerometer")
-	devicePath        = flag.String("dev-path", "/dev/spidev0.0", "The dev path of the spi device. Default is /dev/spidev0.0")
-	maxSamples        = flag.Int("max-samples", 200, "The maximum number of samples to take for calibration. Default is 200")
-	clearCalibration  = flag.Bool("clear-calibration", false, "Clear existing calibration data from the IMU")
-	verifyCalibration = flag.Bool("verify-calibration", false, "Verify that measured values make sense.")
+	sensor                    = flag.String("sensor", "", "The sensor to calibrate or clear. Required. Values: gyro,accelerometer")
+	devicePath                = flag.String("dev-path", "/dev/spidev0.0", "The dev path of the spi device. Default is /dev/spidev0.0")
+	maxSamples                = flag.Int("max-samples", 200, "The maximum number of samples to take for calibration. Default is 200")
+	clearCalibration          = flag.Bool("clear-calibration", false, "Clear existing calibration data from the IMU")
+	verifyCalibration         = flag.Bool("verify-calibration", false, "Verify that measured values make sense.")
+	output                    = flag.String("output", "text", "Format of the verification report. Values: text,json")
+	gyroBiasTolerance         = flag.Float64("gyro-bias-tolerance", defaultTolerances.GyroBias, "Maximum residual gyro bias per axis in dps")
+	accelerationBiasTolerance = flag.Float64("acceleration-bias-tolerance", defaultTolerances.AccelerationBias, "Maximum residual accelerometer bias per axis in g")
+	expectedGravity           = flag.Float64("expected-gravity", defaultTolerances.ExpectedGravity, "Magnitude in g measured by the accelerometer at rest once calibrated")
+	gravityErrorTolerance     = flag.Float64("gravity-error-tolerance", defaultTolerances.GravityError, "Maximum difference in g between the measured and expected gravity")
 )
 
-func abs(value int32) int32 {
-	if value >= 0 {
-		return value
-	}
-	return -value
-}
-
-// Found by experimenting.
-const (
-	accelerometerVerificationCutoff int32 = 15
-	gyroVerificationCutoff          int32 = 7
+// The verification report is written to reportWriter. Progress messages and
+// driver logs go to logWriter, stderr in json mode so that stdout only holds
+// the report.
+var (
+	reportWriter io.Writer = os.Stdout
+	logWriter    io.Writer = os.Stdout
 )
 
+func tolerances() iim42652.VerificationTolerances {
+	t := defaultTolerances
+	t.GyroBias = *gyroBiasTolerance
+	t.AccelerationBias = *accelerationBiasTolerance
+	t.ExpectedGravity = *expectedGravity
+	t.GravityError = *gravityErrorTolerance
+	return t
+}
+
 func calibrateGyro(imuDevice *iim42652.IIM42652) error {
 	bias, err := imuDevice.CalibrateGyro(int32(*maxSamples))
 	if err != nil {
 		return fmt.Errorf("calibrating gyro: %w", err)
 	}
-	fmt.Println("gyro calibration values:", bias)
+	fmt.Fprintln(logWriter, "gyro calibration values:", bias)
 
 	time.Sleep(60 * time.Millisecond)
-	result, err := verifyGyro(imuDevice)
+	report, err := verifyGyro(imuDevice)
 	if err != nil {
 		return err
 	}
-	if !result {
-		fmt.Println("Gyro values were not in expected range. Consider clearing and recalibrating.")
+	if !report.Passed {
+		fmt.Fprintln(logWriter, "Gyro values were not in expected range. Consider clearing and recalibrating.")
 	}
 	return nil
 }
 
-func verifyGyro(imuDevice *iim42652.IIM42652) (result bool, err error) {
-	average, err := imuDevice.AverageGyroSensorOutput(int32(*maxSamples))
+func verifyGyro(imuDevice *iim42652.IIM42652) (*iim42652.VerificationReport, error) {
+	verification, err := imuDevice.VerifyGyroCalibration(int32(*maxSamples), tolerances())
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	fmt.Printf("verifyGyro: raw reading {X: %v Y: %v Z: %v}\n", average[0], average[1], average[2])
-	cutoff := gyroVerificationCutoff
-	if abs(average[0]) >= cutoff || abs(average[1]) >= cutoff || abs(average[2]) >= cutoff {
-		return false, nil
+	report := iim42652.NewVerificationReport(tolerances(), verification, nil)
+	if err := printReport(report); err != nil {
+		return nil, err
 	}
-	return true, nil
+	return report, nil
 }
 
 func calibrateAccelerometer(imuDevice *iim42652.IIM42652) error {
@@ -91,31 +118,54 @@ func calibrateAccelerometer(imuDevice *iim42652.IIM42652) error {
 	if err != nil {
 		return fmt.Errorf("calibrating accelerometer: %w", err)
 	}
-	fmt.Println("accel calibration values:", bias)
+	fmt.Fprintln(logWriter, "accel calibration values:", bias)
 
 	time.Sleep(60 * time.Millisecond)
-	result, err := verifyAccelerometer(imuDevice)
+	report, err := verifyAccelerometer(imuDevice)
 	if err != nil {
 		return err
 	}
-	if !result {
-		fmt.Println("Accelerometer values were not in expected range. Consider clearing and recalibrating.")
+	if !report.Passed {
+		fmt.Fprintln(logWriter, "Accelerometer values were not in expected range. Consider clearing and recalibrating.")
 	}
 	return nil
 }
 
-func verifyAccelerometer(imuDevice *iim42652.IIM42652) (result bool, err error) {
-	average, err := imuDevice.AverageAccelerometerSensorOutput(int32(*maxSamples))
+func verifyAccelerometer(imuDevice *iim42652.IIM42652) (*iim42652.VerificationReport, error) {
+	verification, err := imuDevice.VerifyAccelerometerCalibration(int32(*maxSamples), tolerances())
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	report := iim42652.NewVerificationReport(tolerances(), nil, verification)
+	if err := printReport(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func printReport(report *iim42652.VerificationReport) error {
+	if *output == "json" {
+		encoder := json.NewEncoder(reportWriter)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
 	}
 
-	fmt.Printf("verifyAccelerometer: raw reading {X: %v Y: %v Z: %v}\n", average[0], average[1], average[2])
-	cutoff := accelerometerVerificationCutoff
-	if abs(average[0]) >= cutoff || abs(average[1]) >= cutoff || abs(average[2]) >= cutoff {
-		return false, nil
+	for _, verification := range []*iim42652.SensorVerification{report.Gyro, report.Accelerometer} {
+		if verification == nil {
+			continue
+		}
+		fmt.Fprintf(reportWriter, "%s: %d samples (%d discarded)\n", verification.Sensor, verification.Samples, verification.Discarded)
+		fmt.Fprintf(reportWriter, "  residual bias (%s): %+v\n", verification.Unit, verification.ResidualBias)
+		fmt.Fprintf(reportWriter, "  noise density (%s/√Hz): %+v\n", verification.Unit, verification.NoiseDensity)
+		if verification.GravityError != nil {
+			fmt.Fprintf(reportWriter, "  gravity error (g): %.5f\n", *verification.GravityError)
+		}
+		for _, failure := range verification.Failures {
+			fmt.Fprintln(reportWriter, "  FAILED:", failure)
+		}
 	}
-	return true, nil
+	return nil
 }
 
 func validateFlags() error {
@@ -125,6 +175,9 @@ func validateFlags() error {
 	if *sensor != "gyro" && *sensor != "accelerometer" {
 		return fmt.Errorf("sensor '%v' not recognized, must be 'gyro' or 'accelerometer'", *sensor)
 	}
+	if *output != "text" && *output != "json" {
+		return fmt.Errorf("output '%v' not recognized, must be 'text' or 'json'", *output)
+	}
 	return nil
 }
 
@@ -135,8 +188,14 @@ func main() {
 		panic(fmt.Errorf("validateflags: %w", err))
 	}
 
-	// Note: Only 16G and 2000dps work. iim42652 module does not
-	// configure these parameters on the device and relies on the defaults.
+	if *output == "json" {
+		logWriter = os.Stderr
+	}
+
+	// Note: iim42652 module does not configure the full scale ranges on the
+	// device and relies on the defaults (16G and 2000dps). The bias written to
+	// the user registers is converted from the range configured on the device,
+	// the sensitivities below are only used to scale the verification report.
 	imuDevice := iim42652.NewSpi(
 		*devicePath,
 		iim42652.AccelerationSensitivityG16,
@@ -144,6 +203,7 @@ func main() {
 		true,
 		false, // skip power management
 	)
+	imuDevice.SetLogOutput(logWriter)
 
 	err := imuDevice.Init()
 	if err != nil {
@@ -156,23 +216,27 @@ func main() {
 			if err != nil {
 				panic(fmt.Errorf("clearing IMU: %w", err))
 			}
-			fmt.Println("Gyro cleared!")
+			fmt.Fprintln(logWriter, "Gyro cleared!")
 		} else if *verifyCalibration {
-			result, err := verifyGyro(imuDevice)
+			report, err := verifyGyro(imuDevice)
 			if err != nil {
 				panic(fmt.Errorf("verifying Gyro: %w", err))
 			}
-			if !result {
-				fmt.Println("Gyro verification failed!")
+			if !report.Passed {
+				if *output == "text" {
+					fmt.Fprintln(logWriter, "Gyro verification failed!")
+				}
 				os.Exit(1)
 			}
-			fmt.Println("Gyro verified!")
+			if *output == "text" {
+				fmt.Fprintln(logWriter, "Gyro verified!")
+			}
 		} else {
 			err := calibrateGyro(imuDevice)
 			if err != nil {
 				panic(fmt.Errorf("calibrating IMU: %w", err))
 			}
-			fmt.Println("Gyro calibrated!")
+			fmt.Fprintln(logWriter, "Gyro calibrated!")
 		}
 	} else {
 		if *clearCalibration {
@@ -180,23 +244,27 @@ func main() {
 			if err != nil {
 				panic(fmt.Errorf("clearing IMU: %w", err))
 			}
-			fmt.Println("Accelerometer cleared!")
+			fmt.Fprintln(logWriter, "Accelerometer cleared!")
 		} else if *verifyCalibration {
-			result, err := verifyAccelerometer(imuDevice)
+			report, err := verifyAccelerometer(imuDevice)
 			if err != nil {
 				panic(fmt.Errorf("verifying Accelerometer: %w", err))
 			}
-			if !result {
-				fmt.Println("Accelerometer verification failed!")
+			if !report.Passed {
+				if *output == "text" {
+					fmt.Fprintln(logWriter, "Accelerometer verification failed!")
+				}
 				os.Exit(1)
 			}
-			fmt.Println("Accelerometer verified!")
+			if *output == "text" {
+				fmt.Fprintln(logWriter, "Accelerometer verified!")
+			}
 		} else {
 			err := calibrateAccelerometer(imuDevice)
 			if err != nil {
 				panic(fmt.Errorf("calibrating IMU: %w", err))
 			}
-			fmt.Println("Accelerometer calibrated!")
+			fmt.Fprintln(logWriter, "Accelerometer calibrated!")
 		}
 
 	}
@@ -0,0 +1,93 @@
+/*
+imuconvert converts a binary IMU recording to CSV or JSON Lines.
+
+Usage:
+
+	imuconvert [flags] <recording>
+
+The flags are:
+
+	--format
+		Output format, 'csv' or 'jsonl'. Default is 'csv'
+	--output
+		File the samples are written to, '-' for stdout. Default is '-'
+
+Camera frame values use the mounting stored in the recording, its axis map
+when the mounting is unknown, or the default axis map.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/export"
+	"github.com/streamingfast/imu-controller/recording"
+)
+
+var (
+	format = flag.String("format", "csv", "Output format. Values: csv,jsonl")
+	output = flag.String("output", "-", "File the samples are written to, '-' for stdout")
+)
+
+func convert(path string) (int, error) {
+	input, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer input.Close()
+
+	reader, err := recording.NewReader(input)
+	if err != nil {
+		return 0, fmt.Errorf("reading %q: %w", path, err)
+	}
+	transform, err := export.TransformFromHeader(reader.Header())
+	if err != nil {
+		return 0, fmt.Errorf("recording mounting: %w", err)
+	}
+
+	if *output == "-" {
+		return write(reader, os.Stdout, transform)
+	}
+	out, err := os.Create(*output)
+	if err != nil {
+		return 0, err
+	}
+	count, err := write(reader, out, transform)
+	// The data may only reach the disk on close, its error fails the conversion.
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("closing %q: %w", *output, closeErr)
+	}
+	return count, err
+}
+
+func write(reader *recording.Reader, out io.Writer, transform *iim42652.MountTransform) (int, error) {
+	buffered := bufio.NewWriter(out)
+	encoder, err := export.NewEncoder(export.Format(*format), buffered, transform)
+	if err != nil {
+		return 0, err
+	}
+	count, err := export.Convert(reader, encoder)
+	if err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: imuconvert [flags] <recording>")
+		os.Exit(2)
+	}
+
+	count, err := convert(flag.Arg(0))
+	if err != nil {
+		panic(fmt.Errorf("converting: %w", err))
+	}
+	fmt.Fprintf(os.Stderr, "%d samples converted\n", count)
+}
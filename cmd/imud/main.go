@@ -0,0 +1,233 @@
+/*
+imud owns the IIM42652 and serves its samples, the events detected in them,
+its configuration and self-test over a gRPC API on a Unix socket, see
+proto/imu/v1/service.proto.
+
+Usage:
+
+	imud [flags]
+
+The flags are:
+
+	--dev-path
+		Path to the spi device. By default, this is '/dev/spidev0.0'
+	--socket
+		Path of the Unix socket served. Default is '/run/imud.sock'
+	--period
+		Time between two samples. Default is 10ms
+	--driving-config
+		Path to the driving event rules, like imu-logger.json. Driving events
+		are not detected when empty
+	--buffer-size
+		Number of samples and events buffered for every subscriber. Default is 256
+	--low-power
+		Turn the gyroscope off while the vehicle is parked, the significant
+		motion interrupt wakes it up. Default is true
+	--http-addr
+		Address of the HTTP server showing the live values, like ':8080'. The
+		HTTP server is not started when empty
+	--metrics-addr
+		Address serving the Prometheus metrics of the driver on /metrics, like
+		':9100'. The metrics are not served when empty
+	--shm-path
+		Path of the shared memory ring buffer the samples are published to,
+		like '/dev/shm/imu', see the shm package. Not published when empty
+	--shm-capacity
+		Number of samples held by the ring buffer. Default is 4096
+	--mqtt-broker
+		URL of the MQTT broker the events and summaries are published to, like
+		'tcp://localhost:1883'. Not published when empty
+	--mqtt-client-id
+		MQTT client identifier. Default is 'imud'
+	--mqtt-topic-prefix
+		Prefix of the driving, impact, motion_state and summary topics. Default
+		is 'imu'
+	--mqtt-qos
+		Quality of service of the messages published. Default is 1
+	--mqtt-summary-interval
+		Time between two summaries. Default is 1m
+
+The socket file is replaced when the daemon starts, its permissions follow the
+umask. Read errors are logged to stderr and the sensor is read again, SIGINT
+and SIGTERM stop the daemon.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/streamingfast/imu-controller/daemon"
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/hub"
+	"github.com/streamingfast/imu-controller/metrics"
+	"github.com/streamingfast/imu-controller/mqtt"
+	"github.com/streamingfast/imu-controller/shm"
+	"github.com/streamingfast/imu-controller/web"
+)
+
+var (
+	devicePath    = flag.String("dev-path", "/dev/spidev0.0", "The dev path of the spi device. Default is /dev/spidev0.0")
+	socket        = flag.String("socket", "/run/imud.sock", "Path of the Unix socket served")
+	period        = flag.Duration("period", 10*time.Millisecond, "Time between two samples")
+	drivingConfig = flag.String("driving-config", "", "Path to the driving event rules, driving events are not detected when empty")
+	bufferSize    = flag.Int("buffer-size", 256, "Number of samples and events buffered for every subscriber")
+	lowPower      = flag.Bool("low-power", true, "Turn the gyroscope off while the vehicle is parked")
+	httpAddr      = flag.String("http-addr", "", "Address of the HTTP server showing the live values, not started when empty")
+	metricsAddr   = flag.String("metrics-addr", "", "Address serving the Prometheus metrics on /metrics, not served when empty")
+	shmPath       = flag.String("shm-path", "", "Path of the shared memory ring buffer the samples are published to, not published when empty")
+	shmCapacity   = flag.Int("shm-capacity", 4096, "Number of samples held by the shared memory ring buffer")
+
+	mqttBroker          = flag.String("mqtt-broker", "", "URL of the MQTT broker the events and summaries are published to, not published when empty")
+	mqttClientID        = flag.String("mqtt-client-id", "imud", "MQTT client identifier")
+	mqttTopicPrefix     = flag.String("mqtt-topic-prefix", "imu", "Prefix of the MQTT topics")
+	mqttQoS             = flag.Uint("mqtt-qos", 1, "Quality of service of the MQTT messages")
+	mqttSummaryInterval = flag.Duration("mqtt-summary-interval", time.Minute, "Time between two summaries published to MQTT")
+)
+
+func logError(err error) {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.RFC3339), err)
+}
+
+func main() {
+	flag.Parse()
+
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	if err != nil {
+		panic(fmt.Errorf("building mount transform: %w", err))
+	}
+	config := hub.DefaultConfig(transform)
+	config.Period = *period
+	config.BufferSize = *bufferSize
+	config.OnError = logError
+	if *drivingConfig != "" {
+		if config.Events.Driving, err = driving.LoadConfig(*drivingConfig); err != nil {
+			panic(fmt.Errorf("loading driving config: %w", err))
+		}
+	}
+
+	var prometheusMetrics *metrics.Prometheus
+	imuDevice := iim42652.NewSpi(
+		*devicePath,
+		iim42652.AccelerationSensitivityG16,
+		iim42652.GyroScalesG2000,
+		false,
+		false,
+	)
+	if *metricsAddr != "" {
+		if prometheusMetrics, err = metrics.NewPrometheus(prometheus.NewRegistry()); err != nil {
+			panic(fmt.Errorf("creating metrics: %w", err))
+		}
+		imuDevice.SetMetrics(prometheusMetrics)
+	}
+	if err := imuDevice.Init(); err != nil {
+		panic(fmt.Errorf("initializing IMU: %w", err))
+	}
+	if *lowPower {
+		config.Power = imuDevice
+	}
+
+	h, err := hub.New(imuDevice, config)
+	if err != nil {
+		panic(fmt.Errorf("creating hub: %w", err))
+	}
+
+	listener, err := daemon.Listen(*socket)
+	if err != nil {
+		panic(fmt.Errorf("listening: %w", err))
+	}
+	defer os.Remove(*socket)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if prometheusMetrics != nil {
+		go func() {
+			if err := prometheusMetrics.ListenAndServe(ctx, *metricsAddr); err != nil {
+				logError(fmt.Errorf("serving metrics: %w", err))
+			}
+		}()
+	}
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- daemon.NewServer(h, imuDevice).Serve(ctx, listener)
+	}()
+
+	httpDone := make(chan error, 1)
+	if *httpAddr == "" {
+		httpDone <- nil
+	} else {
+		webServer, err := web.NewServer(h, imuDevice, web.DefaultConfig())
+		if err != nil {
+			panic(fmt.Errorf("creating web server: %w", err))
+		}
+		go func() {
+			httpDone <- webServer.ListenAndServe(ctx, *httpAddr)
+		}()
+	}
+
+	publishDone := make(chan error, 1)
+	if *shmPath == "" {
+		publishDone <- nil
+	} else {
+		publisher, err := shm.Create(*shmPath, *shmCapacity)
+		if err != nil {
+			panic(fmt.Errorf("creating ring buffer: %w", err))
+		}
+		// The subscription is closed when the hub stops.
+		subscription := h.Subscribe()
+		go func() {
+			for sample := range subscription.Samples() {
+				publisher.Publish(sample)
+			}
+			publishDone <- publisher.Close()
+		}()
+	}
+
+	mqttDone := make(chan error, 1)
+	if *mqttBroker == "" {
+		mqttDone <- nil
+	} else {
+		mqttConfig := mqtt.DefaultConfig(*mqttBroker)
+		mqttConfig.ClientID = *mqttClientID
+		mqttConfig.QoS = byte(*mqttQoS)
+		mqttConfig.DrivingTopic = *mqttTopicPrefix + "/driving"
+		mqttConfig.ImpactTopic = *mqttTopicPrefix + "/impact"
+		mqttConfig.MotionStateTopic = *mqttTopicPrefix + "/motion_state"
+		mqttConfig.SummaryTopic = *mqttTopicPrefix + "/summary"
+		mqttConfig.SummaryInterval = *mqttSummaryInterval
+		mqttConfig.OnError = logError
+		publisher, err := mqtt.NewPublisher(h, mqttConfig)
+		if err != nil {
+			panic(fmt.Errorf("creating MQTT publisher: %w", err))
+		}
+		go func() {
+			mqttDone <- publisher.Run(ctx)
+		}()
+	}
+
+	if err := h.Run(ctx); err != nil {
+		logError(fmt.Errorf("reading device: %w", err))
+	}
+	cancel()
+	if err := <-serverDone; err != nil {
+		logError(fmt.Errorf("serving: %w", err))
+	}
+	if err := <-httpDone; err != nil {
+		logError(fmt.Errorf("serving HTTP: %w", err))
+	}
+	if err := <-mqttDone; err != nil {
+		logError(fmt.Errorf("publishing to MQTT: %w", err))
+	}
+	if err := <-publishDone; err != nil {
+		logError(fmt.Errorf("closing ring buffer: %w", err))
+	}
+}
@@ -0,0 +1,137 @@
+/*
+imulogger logs the IIM42652 samples to rotating recording segments, bounded
+in disk usage.
+
+Usage:
+
+	imulogger [flags]
+
+The flags are:
+
+	--dev-path
+		Path to the spi device. By default, this is '/dev/spidev0.0'
+	--directory
+		Directory the segments are written to. Default is './imu-logs'
+	--prefix
+		Prefix of the segment file names. Default is 'imu'
+	--period
+		Time between two samples. Default is 10ms
+	--max-segment-size
+		Size in bytes past which a segment is closed, 0 disables. Default is 16MiB
+	--max-segment-duration
+		Duration past which a segment is closed, 0 disables. Default is 10m
+	--quota
+		Size in bytes of all the segments past which the oldest are deleted,
+		0 disables. Default is 1GiB
+	--sync-interval
+		How often the current segment is synced to disk, what a power cut
+		loses at most. Default is 2s
+	--compress
+		Gzip the closed segments. Default is true
+	--metrics-addr
+		Address serving the Prometheus metrics of the driver on /metrics, like
+		':9100'. The metrics are not served when empty
+
+Segments are recordings, see the recording package, read them with imuconvert
+once decompressed. Read errors are logged to stderr and the sensor is read
+again, SIGINT and SIGTERM close the current segment and stop the logger.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/logger"
+	"github.com/streamingfast/imu-controller/metrics"
+	"github.com/streamingfast/imu-controller/recording"
+)
+
+var (
+	devicePath         = flag.String("dev-path", "/dev/spidev0.0", "The dev path of the spi device. Default is /dev/spidev0.0")
+	directory          = flag.String("directory", "./imu-logs", "Directory the segments are written to")
+	prefix             = flag.String("prefix", "imu", "Prefix of the segment file names")
+	period             = flag.Duration("period", 10*time.Millisecond, "Time between two samples")
+	maxSegmentSize     = flag.Int64("max-segment-size", 16<<20, "Size in bytes past which a segment is closed, 0 disables")
+	maxSegmentDuration = flag.Duration("max-segment-duration", 10*time.Minute, "Duration past which a segment is closed, 0 disables")
+	quota              = flag.Int64("quota", 1<<30, "Size in bytes of all the segments past which the oldest are deleted, 0 disables")
+	syncInterval       = flag.Duration("sync-interval", 2*time.Second, "How often the current segment is synced to disk")
+	compress           = flag.Bool("compress", true, "Gzip the closed segments")
+	metricsAddr        = flag.String("metrics-addr", "", "Address serving the Prometheus metrics on /metrics, not served when empty")
+)
+
+func logError(err error) {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.RFC3339), err)
+}
+
+func main() {
+	flag.Parse()
+
+	var prometheusMetrics *metrics.Prometheus
+	var err error
+	imuDevice := iim42652.NewSpi(
+		*devicePath,
+		iim42652.AccelerationSensitivityG16,
+		iim42652.GyroScalesG2000,
+		false,
+		false,
+	)
+	if *metricsAddr != "" {
+		if prometheusMetrics, err = metrics.NewPrometheus(prometheus.NewRegistry()); err != nil {
+			panic(fmt.Errorf("creating metrics: %w", err))
+		}
+		imuDevice.SetMetrics(prometheusMetrics)
+	}
+	if err := imuDevice.Init(); err != nil {
+		panic(fmt.Errorf("initializing IMU: %w", err))
+	}
+
+	configuration, err := imuDevice.ReadConfiguration()
+	if err != nil {
+		panic(fmt.Errorf("reading configuration: %w", err))
+	}
+
+	config := logger.DefaultConfig(*directory)
+	config.Prefix = *prefix
+	config.MaxSegmentSize = *maxSegmentSize
+	config.MaxSegmentDuration = *maxSegmentDuration
+	config.Quota = *quota
+	config.SyncInterval = *syncInterval
+	config.Compress = *compress
+	config.OnError = logError
+
+	l, err := logger.New(config, &recording.Header{
+		Device:        *devicePath,
+		Configuration: configuration,
+		AxisMap:       iim42652.DefaultAxisMap(),
+	})
+	if err != nil {
+		panic(fmt.Errorf("creating logger: %w", err))
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if prometheusMetrics != nil {
+		go func() {
+			if err := prometheusMetrics.ListenAndServe(ctx, *metricsAddr); err != nil {
+				logError(fmt.Errorf("serving metrics: %w", err))
+			}
+		}()
+	}
+
+	runErr := logger.Run(ctx, imuDevice, *period, l, logError)
+	if err := l.Close(); err != nil {
+		logError(fmt.Errorf("closing logger: %w", err))
+	}
+	if runErr != nil {
+		panic(fmt.Errorf("logging: %w", runErr))
+	}
+}
@@ -0,0 +1,209 @@
+/*
+imunoise records a stationary IIM42652 and characterizes its noise with the
+Allan deviation.
+
+Usage:
+
+	imunoise [flags]
+
+The flags are:
+
+	--dev-path
+		Path to the spi device. By default, this is '/dev/spidev0.0'
+	--duration
+		How long to record. Default is 3h, bias instability and rate random
+		walk need recordings of several hours.
+	--sample-rate float
+		Rate, in Hz, at which samples are read. Default is 100
+	--points-per-decade int
+		Number of points of the curves per decade of cluster duration. Default is 10
+	--format
+		Format of the output, 'json' or 'csv'. Default is 'json'
+	--output
+		File the output is written to, '-' for stdout. In csv mode the curves
+		are written to it and the coefficients to a second file with a
+		'-coefficients' suffix, or after a blank line on stdout.
+
+The device must not move during the recording. Samples are read in the IMU
+frame, the gyro in dps and the accelerometer in g. Read errors are logged to
+stderr and the sensor is read again. The curves are always written, an axis
+whose coefficients cannot be extracted reports why in its error field.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/imu-controller/allan"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+var (
+	devicePath      = flag.String("dev-path", "/dev/spidev0.0", "The dev path of the spi device. Default is /dev/spidev0.0")
+	duration        = flag.Duration("duration", 3*time.Hour, "How long to record")
+	sampleRate      = flag.Float64("sample-rate", 100, "Rate in Hz at which samples are read")
+	pointsPerDecade = flag.Int("points-per-decade", 10, "Number of points of the curves per decade of cluster duration")
+	format          = flag.String("format", "json", "Format of the output. Values: json,csv")
+	output          = flag.String("output", "-", "File the output is written to, '-' for stdout")
+)
+
+func validateFlags() error {
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("format '%v' not recognized, must be 'json' or 'csv'", *format)
+	}
+	if *sampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %v", *sampleRate)
+	}
+	return nil
+}
+
+// Time waited before streaming again after a read error.
+const retryDelay = time.Second
+
+// record streams the device for the duration. A read error does not throw
+// the recording away: it is logged and the stream restarted, the samples
+// recorded so far are kept.
+func record(imuDevice *iim42652.IIM42652) *allan.Recording {
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+
+	samples := make(chan *iim42652.Sample, 1024)
+	streamErr := make(chan error, 1)
+	stream := func() {
+		go func() {
+			streamErr <- imuDevice.Stream(ctx, time.Duration(float64(time.Second) / *sampleRate), samples)
+		}()
+	}
+	stream()
+
+	recording := allan.NewRecording()
+	// Stream does not send once it returned, keep the samples still buffered.
+	drain := func() *allan.Recording {
+		for {
+			select {
+			case sample := <-samples:
+				recording.Add(sample)
+			default:
+				return recording
+			}
+		}
+	}
+
+	progress := time.NewTicker(time.Minute)
+	defer progress.Stop()
+	// Only set while waiting to stream again.
+	var retry <-chan time.Time
+	var done <-chan struct{}
+	for {
+		select {
+		case sample := <-samples:
+			recording.Add(sample)
+		case <-progress.C:
+			fmt.Fprintf(os.Stderr, "recorded %d samples\n", recording.Len())
+		case err := <-streamErr:
+			if ctx.Err() != nil {
+				return drain()
+			}
+			fmt.Fprintf(os.Stderr, "reading samples, retrying in %s: %s\n", retryDelay, err)
+			retry, done = time.After(retryDelay), ctx.Done()
+		case <-retry:
+			retry, done = nil, nil
+			stream()
+		case <-done:
+			return drain()
+		}
+	}
+}
+
+func writeReport(report *allan.Report) error {
+	if *format == "json" {
+		return withOutput(*output, func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		})
+	}
+
+	if *output == "-" {
+		if err := report.WriteCurveCSV(os.Stdout); err != nil {
+			return err
+		}
+		fmt.Println()
+		return report.WriteCoefficientsCSV(os.Stdout)
+	}
+
+	if err := withOutput(*output, report.WriteCurveCSV); err != nil {
+		return err
+	}
+	extension := filepath.Ext(*output)
+	return withOutput(strings.TrimSuffix(*output, extension)+"-coefficients"+extension, report.WriteCoefficientsCSV)
+}
+
+func withOutput(path string, write func(w io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %q: %w", path, err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("writing %q: %w", path, err)
+	}
+	return file.Close()
+}
+
+func main() {
+	flag.Parse()
+
+	if err := validateFlags(); err != nil {
+		panic(fmt.Errorf("validateflags: %w", err))
+	}
+
+	imuDevice := iim42652.NewSpi(
+		*devicePath,
+		iim42652.AccelerationSensitivityG16,
+		iim42652.GyroScalesG2000,
+		false,
+		false,
+	)
+	// Driver logs go to stderr so that stdout only holds the output.
+	imuDevice.SetLogOutput(os.Stderr)
+	if err := imuDevice.Init(); err != nil {
+		panic(fmt.Errorf("initializing IMU: %w", err))
+	}
+	// Init leaves the accelerometer at 50Hz, reading it faster would repeat
+	// samples and flatten the short cluster durations of the curve.
+	if err := imuDevice.SetAccelerometerOutputDataRate(1000); err != nil {
+		panic(fmt.Errorf("setting accelerometer ODR: %w", err))
+	}
+
+	fmt.Fprintf(os.Stderr, "recording for %s, the device must not move\n", *duration)
+	recording := record(imuDevice)
+
+	report, err := recording.Analyze(*pointsPerDecade)
+	if err != nil {
+		panic(fmt.Errorf("analyzing: %w", err))
+	}
+	for _, sensor := range []*allan.SensorNoise{report.Gyro, report.Accelerometer} {
+		for _, axis := range sensor.Axes {
+			if axis.Error != "" {
+				fmt.Fprintf(os.Stderr, "%s %s coefficients: %s\n", sensor.Sensor, axis.Axis, axis.Error)
+			}
+		}
+	}
+
+	if err := writeReport(report); err != nil {
+		panic(fmt.Errorf("writing report: %w", err))
+	}
+}
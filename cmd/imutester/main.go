@@ -1,19 +1,35 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/export"
+	"github.com/streamingfast/imu-controller/spectrum"
 )
 
-var skipPwrMngt = flag.Bool("skip-power-management", false, "skip power management")
+var (
+	skipPwrMngt = flag.Bool("skip-power-management", false, "skip power management")
+	mode        = flag.String("mode", "raw", "What to print. Values: raw,spectrum")
+	format      = flag.String("format", "text", "Format of the raw samples. Values: text,csv,jsonl")
+	odr         = flag.Float64("odr", 1000, "Accelerometer output data rate in Hz, spectrum mode only")
+	windowSize  = flag.Int("window-size", 1024, "Number of samples per FFT, a power of two, spectrum mode only")
+	averages    = flag.Int("averages", 8, "Number of windows averaged in a printed spectrum, spectrum mode only")
+)
 
 func main() {
 	flag.Parse()
-	devPath := os.Args[1]
+	devPath := flag.Arg(0)
+
+	if *format != "text" && *format != string(export.FormatCSV) && *format != string(export.FormatJSONLines) {
+		panic(fmt.Errorf("format %q not recognized, must be 'text', 'csv' or 'jsonl'", *format))
+	}
 
 	imuDevice := iim42652.NewSpi(
 		devPath,
@@ -22,12 +38,33 @@ func main() {
 		true,
 		*skipPwrMngt,
 	)
+	// Driver logs go to stderr when exporting so that stdout only holds the samples.
+	if *format != "text" {
+		imuDevice.SetLogOutput(os.Stderr)
+	}
 
 	err := imuDevice.Init()
 	if err != nil {
 		panic(fmt.Errorf("initializing IMU: %w", err))
 	}
 
+	switch *mode {
+	case "raw":
+		if *format == "text" {
+			printRaw(imuDevice)
+		} else if err := exportRaw(imuDevice, os.Stdout); err != nil {
+			panic(fmt.Errorf("exporting samples: %w", err))
+		}
+	case "spectrum":
+		if err := printSpectrum(imuDevice); err != nil {
+			panic(fmt.Errorf("analyzing spectrum: %w", err))
+		}
+	default:
+		panic(fmt.Errorf("mode %q not recognized, must be 'raw' or 'spectrum'", *mode))
+	}
+}
+
+func printRaw(imuDevice *iim42652.IIM42652) {
 	for {
 		time.Sleep(10 * time.Millisecond)
 		acceleration, err := imuDevice.GetAcceleration()
@@ -52,3 +89,87 @@ func main() {
 
 	}
 }
+
+// exportRaw writes a sample every 10ms as CSV or JSON Lines, with the camera
+// values of the default axis map.
+func exportRaw(imuDevice *iim42652.IIM42652, w io.Writer) error {
+	transform, err := iim42652.NewMountTransformFromAxisMap(iim42652.DefaultAxisMap())
+	if err != nil {
+		return err
+	}
+	encoder, err := export.NewEncoder(export.Format(*format), w, transform)
+	if err != nil {
+		return err
+	}
+
+	for {
+		time.Sleep(10 * time.Millisecond)
+		sample, err := imuDevice.GetSample()
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(sample); err != nil {
+			return err
+		}
+		if err := encoder.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// printSpectrum samples the accelerometer at the ODR and prints the dominant
+// frequencies and band energies of every spectrum. A resonant peak that does
+// not move with the vehicle speed usually points to a loose mount, and the
+// highest significant frequency helps choosing the anti-alias filter bandwidth.
+func printSpectrum(imuDevice *iim42652.IIM42652) error {
+	if err := imuDevice.SetAccelerometerOutputDataRate(*odr); err != nil {
+		return err
+	}
+
+	config := spectrum.DefaultConfig(*odr)
+	config.WindowSize = *windowSize
+	config.Averages = *averages
+	analyzer, err := spectrum.NewAnalyzer(config)
+	if err != nil {
+		return err
+	}
+
+	transform, err := iim42652.NewMountTransformFromAxisMap(iim42652.DefaultAxisMap())
+	if err != nil {
+		return err
+	}
+
+	samples := make(chan *iim42652.Sample, 1024)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- imuDevice.Stream(context.Background(), time.Duration(float64(time.Second) / *odr), samples)
+	}()
+
+	for {
+		select {
+		case err := <-streamErr:
+			return err
+		case sample := <-samples:
+			if s := analyzer.Process(transform.Sample(sample)); s != nil {
+				printSpectrumReport(s)
+			}
+		}
+	}
+}
+
+func printSpectrumReport(s *spectrum.Spectrum) {
+	fmt.Printf("< -- > %s, %d windows (%d with gaps left out), %.2fHz resolution\n", s.End.Format(time.RFC3339), s.Segments, s.Discarded, s.Resolution)
+	for axis, name := range []string{"forward", "lateral", "vertical"} {
+		var peaks []string
+		for _, peak := range s.Peaks[axis] {
+			peaks = append(peaks, fmt.Sprintf("%.1fHz (%.2e g²/Hz)", peak.Frequency, peak.PSD))
+		}
+		var bands []string
+		for _, band := range s.Bands[axis] {
+			bands = append(bands, fmt.Sprintf("%s: %.4fg", band.Band, band.RMS))
+		}
+		fmt.Printf("%s: rms %.4fg\n", name, s.RMS[axis])
+		fmt.Printf("  peaks: %s\n", strings.Join(peaks, ", "))
+		fmt.Printf("  bands: %s\n", strings.Join(bands, ", "))
+	}
+}
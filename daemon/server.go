@@ -0,0 +1,210 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Controller is the part of *iim42652.IIM42652 the server configures and
+// tests the device with.
+type Controller interface {
+	ReadConfiguration() (*iim42652.Configuration, error)
+	SetAccelerometerOutputDataRate(rate float64) error
+	SetGyroOutputDataRate(rate float64) error
+	SelfTest() (*iim42652.SelfTestReport, error)
+}
+
+// Server implements the IMU gRPC service on top of a hub reading the device.
+type Server struct {
+	imuv1.UnimplementedIMUServer
+
+	hub        *hub.Hub
+	controller Controller
+	// Serializes the configuration reads, changes and self-tests, a read
+	// during a self-test would report the test settings.
+	lock sync.Mutex
+}
+
+func NewServer(h *hub.Hub, controller Controller) *Server {
+	return &Server{hub: h, controller: controller}
+}
+
+// Listen listens on the Unix socket at path, replacing the socket left by a
+// previous run.
+func Listen(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("removing previous socket: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %q: %w", path, err)
+	}
+	return listener, nil
+}
+
+// Serve serves the IMU service on listener until ctx is done.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := grpc.NewServer()
+	imuv1.RegisterIMUServer(server, s)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	return server.Serve(listener)
+}
+
+func (s *Server) StreamSamples(request *imuv1.StreamSamplesRequest, stream imuv1.IMU_StreamSamplesServer) error {
+	subscription := s.hub.Subscribe()
+	defer s.hub.Unsubscribe(subscription)
+
+	var transform *iim42652.MountTransform
+	if request.CameraFrame {
+		transform = s.hub.Transform()
+	}
+	decimation := uint64(request.Decimation)
+	if decimation == 0 {
+		decimation = 1
+	}
+
+	for count := uint64(0); ; count++ {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case sample, ok := <-subscription.Samples():
+			if !ok {
+				return status.Error(codes.Unavailable, "device stopped")
+			}
+			if count%decimation != 0 {
+				continue
+			}
+			if err := stream.Send(imuv1.NewSample(sample, transform)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) StreamEvents(request *imuv1.StreamEventsRequest, stream imuv1.IMU_StreamEventsServer) error {
+	types := map[events.Type]bool{}
+	for _, eventType := range request.Types {
+		t, found := eventType.ToType()
+		if !found {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %s", eventType)
+		}
+		types[t] = true
+	}
+
+	subscription := s.hub.Subscribe()
+	defer s.hub.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return status.Error(codes.Unavailable, "device stopped")
+			}
+			if len(types) > 0 && !types[event.Type] {
+				continue
+			}
+			if err := stream.Send(imuv1.NewEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) GetCalibration(ctx context.Context, request *imuv1.GetCalibrationRequest) (*imuv1.Calibration, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	configuration, err := s.controller.ReadConfiguration()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "reading configuration: %s", err)
+	}
+	return imuv1.NewCalibration(configuration, s.hub.Transform()), nil
+}
+
+func (s *Server) GetConfig(ctx context.Context, request *imuv1.GetConfigRequest) (*imuv1.Config, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.config()
+}
+
+func (s *Server) config() (*imuv1.Config, error) {
+	configuration, err := s.controller.ReadConfiguration()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "reading configuration: %s", err)
+	}
+	return imuv1.NewConfig(configuration, s.hub.Period()), nil
+}
+
+// SetConfig changes the output data rates. The sample period of the hub is
+// fixed when the daemon starts, rates slower than it are rejected since the
+// same sample would be read several times.
+func (s *Server) SetConfig(ctx context.Context, request *imuv1.SetConfigRequest) (*imuv1.Config, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sampleRate := 1 / s.hub.Period().Seconds()
+	for _, rate := range []struct {
+		sensor string
+		odr    *float64
+	}{{"accelerometer", request.AccelerometerOdrHz}, {"gyro", request.GyroOdrHz}} {
+		if rate.odr != nil && *rate.odr < sampleRate {
+			return nil, status.Errorf(codes.InvalidArgument, "%s output data rate of %gHz is slower than the %gHz the samples are read at", rate.sensor, *rate.odr, sampleRate)
+		}
+	}
+	if request.AccelerometerOdrHz != nil {
+		if err := s.controller.SetAccelerometerOutputDataRate(*request.AccelerometerOdrHz); err != nil {
+			return nil, configError("setting accelerometer output data rate", err)
+		}
+	}
+	if request.GyroOdrHz != nil {
+		if err := s.controller.SetGyroOutputDataRate(*request.GyroOdrHz); err != nil {
+			return nil, configError("setting gyro output data rate", err)
+		}
+	}
+	return s.config()
+}
+
+func configError(message string, err error) error {
+	if errors.Is(err, iim42652.ErrUnsupportedOutputDataRate) {
+		return status.Errorf(codes.InvalidArgument, "%s: %s", message, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %s", message, err)
+}
+
+func (s *Server) SelfTest(ctx context.Context, request *imuv1.SelfTestRequest) (*imuv1.SelfTestReport, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var report *iim42652.SelfTestReport
+	err := s.hub.Pause(ctx, func() (err error) {
+		report, err = s.controller.SelfTest()
+		return err
+	})
+	switch {
+	case errors.Is(err, hub.ErrStopped):
+		return nil, status.Error(codes.Unavailable, "device stopped")
+	case ctx.Err() != nil:
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "running self-test: %s", err)
+	}
+	return imuv1.NewSelfTestReport(report), nil
+}
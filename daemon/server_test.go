@@ -0,0 +1,222 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+	"github.com/streamingfast/imu-controller/replay/replaytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var start = replaytest.Start
+
+type fakeController struct {
+	configuration iim42652.Configuration
+	selfTests     int
+	// When set, SelfTest applies the test settings and waits for release.
+	testing chan struct{}
+	release chan struct{}
+}
+
+func (c *fakeController) ReadConfiguration() (*iim42652.Configuration, error) {
+	configuration := c.configuration
+	return &configuration, nil
+}
+
+func (c *fakeController) SetAccelerometerOutputDataRate(rate float64) error {
+	if rate == 60 {
+		return fmt.Errorf("%w: accelerometer at 60Hz", iim42652.ErrUnsupportedOutputDataRate)
+	}
+	c.configuration.AccelerometerODR = rate
+	return nil
+}
+
+func (c *fakeController) SetGyroOutputDataRate(rate float64) error {
+	c.configuration.GyroODR = rate
+	return nil
+}
+
+func (c *fakeController) SelfTest() (*iim42652.SelfTestReport, error) {
+	c.selfTests++
+	if c.release != nil {
+		saved := c.configuration
+		c.configuration.GyroFullScale, c.configuration.AccelerometerFullScale = 250, 4
+		close(c.testing)
+		<-c.release
+		c.configuration = saved
+	}
+	return iim42652.NewSelfTestReport(
+		&iim42652.SensorSelfTest{Passed: true, Response: iim42652.AxisValues{X: 20}},
+		&iim42652.SensorSelfTest{Passed: false, Failures: []string{"X response 0.010 outside [0.05, 1.2]"}},
+	), nil
+}
+
+func Test_Server(t *testing.T) {
+	device := replaytest.NewGatedDevice(t)
+	controller := &fakeController{configuration: iim42652.Configuration{
+		AccelerometerFullScale: 16,
+		AccelerometerODR:       50,
+		GyroFullScale:          2000,
+		GyroODR:                1000,
+		GyroOffsets:            [3]int16{32, 0, -64},
+		AccelerometerOffsets:   [3]int16{0, 2000, 0},
+	}}
+
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	h, err := hub.New(device, hub.DefaultConfig(transform))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hubDone := make(chan error)
+	go func() { hubDone <- h.Run(ctx) }()
+
+	socket := filepath.Join(t.TempDir(), "imud.sock")
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+	serverDone := make(chan error)
+	go func() { serverDone <- NewServer(h, controller).Serve(ctx, listener) }()
+
+	connection, err := grpc.Dial("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer connection.Close()
+	client := imuv1.NewIMUClient(connection)
+
+	// Configuration and self-test.
+	config, err := client.SetConfig(ctx, &imuv1.SetConfigRequest{AccelerometerOdrHz: ptr(200.0)})
+	require.NoError(t, err)
+	assert.Equal(t, 200.0, config.AccelerometerOdrHz)
+	assert.Equal(t, 1000.0, config.GyroOdrHz)
+	assert.Equal(t, 10*time.Millisecond, config.SamplePeriod.AsDuration())
+
+	_, err = client.SetConfig(ctx, &imuv1.SetConfigRequest{AccelerometerOdrHz: ptr(60.0)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SetConfig(ctx, &imuv1.SetConfigRequest{GyroOdrHz: ptr(50.0)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "slower than the 10ms sample period")
+	config, err = client.GetConfig(ctx, &imuv1.GetConfigRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, config.GyroOdrHz)
+
+	calibration, err := client.GetCalibration(ctx, &imuv1.GetCalibrationRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &imuv1.Vector{X: 1, Z: -2}, calibration.GyroOffsets)
+	assert.Equal(t, &imuv1.Vector{Y: 1}, calibration.AccelerometerOffsets)
+	assert.Equal(t, []float64{0, 0, 1, 1, 0, 0, 0, 1, 0}, calibration.Mounting)
+
+	report, err := client.SelfTest(ctx, &imuv1.SelfTestRequest{})
+	require.NoError(t, err)
+	assert.False(t, report.Passed)
+	assert.Equal(t, 20.0, report.Gyro.Response.X)
+	assert.Equal(t, []string{"X response 0.010 outside [0.05, 1.2]"}, report.Accelerometer.Failures)
+	assert.Equal(t, 1, controller.selfTests)
+
+	// Independent subscriptions with their own filters.
+	samples, err := client.StreamSamples(ctx, &imuv1.StreamSamplesRequest{Decimation: 10, CameraFrame: true})
+	require.NoError(t, err)
+	allEvents, err := client.StreamEvents(ctx, &imuv1.StreamEventsRequest{})
+	require.NoError(t, err)
+	impacts, err := client.StreamEvents(ctx, &imuv1.StreamEventsRequest{Types: []imuv1.EventType{imuv1.EventType_EVENT_TYPE_IMPACT}})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return h.Subscribers() == 3 }, time.Second, time.Millisecond)
+	device.Start()
+
+	var received []*imuv1.Sample
+	for {
+		sample, err := samples.Recv()
+		if err != nil {
+			assert.Equal(t, codes.Unavailable, status.Code(err), "the replay ended")
+			break
+		}
+		received = append(received, sample)
+	}
+	require.Len(t, received, 11)
+	assert.Equal(t, start.Add(900*time.Millisecond), received[9].Time.AsTime())
+	assert.Equal(t, int32(90), received[9].RawAngularRate.Z)
+	assert.InDelta(t, 1.0, received[9].Acceleration.Y, 0.001)
+	assert.InDelta(t, 1.0, received[9].CameraAcceleration.Z, 0.001)
+
+	var types []string
+	for _, stream := range []imuv1.IMU_StreamEventsClient{allEvents, impacts} {
+		var streamTypes []string
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				assert.Equal(t, codes.Unavailable, status.Code(err))
+				break
+			}
+			streamTypes = append(streamTypes, fmt.Sprintf("%T", event.Event))
+		}
+		types = append(types, fmt.Sprint(streamTypes))
+	}
+	assert.Equal(t, []string{
+		"[*imuv1.Event_MotionState *imuv1.Event_Impact]",
+		"[*imuv1.Event_Impact]",
+	}, types)
+
+	require.NoError(t, <-hubDone)
+	_, err = client.SelfTest(ctx, &imuv1.SelfTestRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	cancel()
+	require.NoError(t, <-serverDone)
+	_, err = client.GetConfig(context.Background(), &imuv1.GetConfigRequest{})
+	assert.Error(t, err)
+}
+
+func Test_ConfigDuringSelfTest(t *testing.T) {
+	controller := &fakeController{
+		configuration: iim42652.Configuration{AccelerometerFullScale: 16, GyroFullScale: 2000},
+		testing:       make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	h, err := hub.New(replaytest.NewGatedDevice(t), hub.DefaultConfig(transform))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx)
+	server := NewServer(h, controller)
+
+	selfTestDone := make(chan error)
+	go func() {
+		_, err := server.SelfTest(ctx, &imuv1.SelfTestRequest{})
+		selfTestDone <- err
+	}()
+	<-controller.testing
+
+	// The configuration is read once the test settings are restored.
+	configs := make(chan *imuv1.Config)
+	go func() {
+		config, err := server.GetConfig(ctx, &imuv1.GetConfigRequest{})
+		assert.NoError(t, err)
+		configs <- config
+	}()
+	select {
+	case <-configs:
+		t.Fatal("configuration read during the self-test")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(controller.release)
+	require.NoError(t, <-selfTestDone)
+	config := <-configs
+	assert.Equal(t, 2000.0, config.GyroFullScaleDps)
+	assert.Equal(t, 16.0, config.AccelerometerFullScaleG)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
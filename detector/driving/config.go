@@ -0,0 +1,62 @@
+package driving
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config holds the driving event rules, as found in imu-logger.json.
+// Thresholds are in g and apply to the gravity free acceleration of the
+// vehicle: lateral is positive to the left, forward is positive when
+// accelerating.
+type Config struct {
+	// Number of consecutive samples that must cross a threshold for an event
+	// to start, and stay under it for the event to end.
+	ContinuousCountWindow int `json:"continuous_count_window"`
+	// Minimum magnitude of the horizontal acceleration for a sample to count.
+	MinimumMagnitudeThreshold float64 `json:"minimum_magnitude_threshold"`
+	LeftTurnThreshold         float64 `json:"left_turn_threshold"`
+	RightTurnThreshold        float64 `json:"right_turn_threshold"`
+	AcceleratorThreshold      float64 `json:"g_force_accelerator_threshold"`
+	DeceleratorThreshold      float64 `json:"g_force_decelerator_threshold"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %q: %w", path, err)
+	}
+
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("decoding config %q: %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %q: %w", path, err)
+	}
+	return config, nil
+}
+
+func (c *Config) Validate() error {
+	if c.ContinuousCountWindow < 1 {
+		return fmt.Errorf("continuous_count_window must be at least 1, got %d", c.ContinuousCountWindow)
+	}
+	if c.MinimumMagnitudeThreshold < 0 {
+		return fmt.Errorf("minimum_magnitude_threshold must be positive, got %v", c.MinimumMagnitudeThreshold)
+	}
+	if c.LeftTurnThreshold <= 0 {
+		return fmt.Errorf("left_turn_threshold must be positive, got %v", c.LeftTurnThreshold)
+	}
+	if c.RightTurnThreshold >= 0 {
+		return fmt.Errorf("right_turn_threshold must be negative, got %v", c.RightTurnThreshold)
+	}
+	if c.AcceleratorThreshold <= 0 {
+		return fmt.Errorf("g_force_accelerator_threshold must be positive, got %v", c.AcceleratorThreshold)
+	}
+	if c.DeceleratorThreshold >= 0 {
+		return fmt.Errorf("g_force_decelerator_threshold must be negative, got %v", c.DeceleratorThreshold)
+	}
+	return nil
+}
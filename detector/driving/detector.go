@@ -0,0 +1,169 @@
+package driving
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/fusion"
+)
+
+type EventType string
+
+const (
+	EventTypeLeftTurn         EventType = "left_turn"
+	EventTypeRightTurn        EventType = "right_turn"
+	EventTypeHardAcceleration EventType = "hard_acceleration"
+	EventTypeHardBraking      EventType = "hard_braking"
+)
+
+// Event is a completed driving event. Peak is the signed acceleration (g)
+// furthest from zero on the event axis.
+type Event struct {
+	Type     EventType     `json:"type"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+	Peak     float64       `json:"peak"`
+}
+
+func (e *Event) String() string {
+	return fmt.Sprintf("Event{type:%s, start:%s, duration:%s, peak:%.3f}", e.Type, e.Start.Format(time.RFC3339Nano), e.Duration, e.Peak)
+}
+
+// Detector applies the Config rules to a stream of linear accelerations. The
+// four event types are tracked independently, a turn can overlap braking.
+type Detector struct {
+	config   *Config
+	trackers []*tracker
+}
+
+func NewDetector(config *Config) (*Detector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	lateral := func(a *fusion.LinearAcceleration) float64 { return a.Lateral }
+	forward := func(a *fusion.LinearAcceleration) float64 { return a.Forward }
+
+	return &Detector{
+		config: config,
+		trackers: []*tracker{
+			{eventType: EventTypeLeftTurn, value: lateral, threshold: config.LeftTurnThreshold},
+			{eventType: EventTypeRightTurn, value: lateral, threshold: config.RightTurnThreshold},
+			{eventType: EventTypeHardAcceleration, value: forward, threshold: config.AcceleratorThreshold},
+			{eventType: EventTypeHardBraking, value: forward, threshold: config.DeceleratorThreshold},
+		},
+	}, nil
+}
+
+// Process feeds one sample and returns the events it completed, if any.
+func (d *Detector) Process(acceleration *fusion.LinearAcceleration) []*Event {
+	horizontal := math.Hypot(acceleration.Forward, acceleration.Lateral)
+	strongEnough := horizontal >= d.config.MinimumMagnitudeThreshold
+
+	var events []*Event
+	for _, t := range d.trackers {
+		if event := t.process(acceleration, strongEnough, d.config.ContinuousCountWindow); event != nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Flush ends the events still in progress, typically when the stream ends.
+func (d *Detector) Flush() []*Event {
+	var events []*Event
+	for _, t := range d.trackers {
+		if t.active {
+			events = append(events, t.end())
+		}
+		t.reset()
+	}
+	return events
+}
+
+// Detect consumes samples until the channel is closed, removes gravity with
+// filter and calls handler for every event.
+func (d *Detector) Detect(samples <-chan *iim42652.CameraSample, filter *fusion.LinearAccelerationFilter, handler func(event *Event)) {
+	for sample := range samples {
+		for _, event := range d.Process(filter.Update(sample)) {
+			handler(event)
+		}
+	}
+	for _, event := range d.Flush() {
+		handler(event)
+	}
+}
+
+type tracker struct {
+	eventType EventType
+	value     func(a *fusion.LinearAcceleration) float64
+	threshold float64
+
+	// Consecutive samples over the threshold, and under it once active.
+	overCount  int
+	underCount int
+	active     bool
+
+	start    time.Time
+	lastOver time.Time
+	peak     float64
+}
+
+func (t *tracker) over(value float64) bool {
+	if t.threshold < 0 {
+		return value <= t.threshold
+	}
+	return value >= t.threshold
+}
+
+func (t *tracker) process(acceleration *fusion.LinearAcceleration, strongEnough bool, window int) *Event {
+	value := t.value(acceleration)
+	if strongEnough && t.over(value) {
+		if t.overCount == 0 && !t.active {
+			t.start = acceleration.Time
+			t.peak = value
+		}
+		t.overCount++
+		t.underCount = 0
+		t.lastOver = acceleration.Time
+		if math.Abs(value) > math.Abs(t.peak) {
+			t.peak = value
+		}
+		if t.overCount >= window {
+			t.active = true
+		}
+		return nil
+	}
+
+	t.overCount = 0
+	if !t.active {
+		return nil
+	}
+
+	t.underCount++
+	if t.underCount < window {
+		return nil
+	}
+	event := t.end()
+	t.reset()
+	return event
+}
+
+func (t *tracker) end() *Event {
+	return &Event{
+		Type:     t.eventType,
+		Start:    t.start,
+		End:      t.lastOver,
+		Duration: t.lastOver.Sub(t.start),
+		Peak:     t.peak,
+	}
+}
+
+func (t *tracker) reset() {
+	t.overCount = 0
+	t.underCount = 0
+	t.active = false
+}
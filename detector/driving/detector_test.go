@@ -0,0 +1,111 @@
+package driving
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/fusion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A segment of identical samples at 100Hz.
+type segment struct {
+	count   int
+	forward float64
+	lateral float64
+}
+
+func Test_Detector(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []segment
+		expected []*Event
+	}{
+		{
+			name:     "quiet",
+			segments: []segment{{count: 100}},
+		},
+		{
+			name:     "left turn",
+			segments: []segment{{count: 10}, {count: 50, lateral: 0.3}, {count: 5, lateral: 0.4}, {count: 20}},
+			expected: []*Event{
+				{Type: EventTypeLeftTurn, Start: at(10), End: at(64), Duration: 540 * time.Millisecond, Peak: 0.4},
+			},
+		},
+		{
+			name:     "right turn with short dip",
+			segments: []segment{{count: 20, lateral: -0.3}, {count: 5}, {count: 20, lateral: -0.25}, {count: 20}},
+			expected: []*Event{
+				{Type: EventTypeRightTurn, Start: at(0), End: at(44), Duration: 440 * time.Millisecond, Peak: -0.3},
+			},
+		},
+		{
+			name:     "spike shorter than the window",
+			segments: []segment{{count: 9, forward: 0.5}, {count: 20}},
+		},
+		{
+			name:     "under minimum magnitude",
+			segments: []segment{{count: 50, lateral: 0.16}, {count: 20}},
+		},
+		{
+			name:     "braking while turning",
+			segments: []segment{{count: 30, forward: -0.3, lateral: 0.2}, {count: 20}},
+			expected: []*Event{
+				{Type: EventTypeLeftTurn, Start: at(0), End: at(29), Duration: 290 * time.Millisecond, Peak: 0.2},
+				{Type: EventTypeHardBraking, Start: at(0), End: at(29), Duration: 290 * time.Millisecond, Peak: -0.3},
+			},
+		},
+		{
+			name:     "acceleration flushed at the end of the stream",
+			segments: []segment{{count: 5}, {count: 30, forward: 0.3}},
+			expected: []*Event{
+				{Type: EventTypeHardAcceleration, Start: at(5), End: at(34), Duration: 290 * time.Millisecond, Peak: 0.3},
+			},
+		},
+	}
+
+	config, err := LoadConfig("../../imu-logger.json")
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector, err := NewDetector(config)
+			require.NoError(t, err)
+
+			var events []*Event
+			n := 0
+			for _, s := range test.segments {
+				for i := 0; i < s.count; i++ {
+					events = append(events, detector.Process(&fusion.LinearAcceleration{
+						Time:    at(n),
+						Forward: s.forward,
+						Lateral: s.lateral,
+					})...)
+					n++
+				}
+			}
+			events = append(events, detector.Flush()...)
+
+			assert.Equal(t, test.expected, events)
+		})
+	}
+}
+
+func Test_LoadConfig(t *testing.T) {
+	config, err := LoadConfig("../../imu-logger.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Config{
+		ContinuousCountWindow:     10,
+		MinimumMagnitudeThreshold: 0.2,
+		LeftTurnThreshold:         0.15,
+		RightTurnThreshold:        -0.15,
+		AcceleratorThreshold:      0.25,
+		DeceleratorThreshold:      -0.25,
+	}, config)
+}
+
+func at(n int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond)
+}
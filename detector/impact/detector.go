@@ -0,0 +1,212 @@
+package impact
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+type Config struct {
+	// Acceleration magnitude (g) triggering an impact. At rest the magnitude
+	// is 1g.
+	Threshold float64
+	// Jerk magnitude (g/s) triggering an impact.
+	JerkThreshold float64
+	// Duration captured before and after the trigger.
+	PreTrigger  time.Duration
+	PostTrigger time.Duration
+	// Expected sample rate (Hz), used to size the pre-trigger buffer.
+	SampleRate float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Threshold:     3,
+		JerkThreshold: 500,
+		PreTrigger:    5 * time.Second,
+		PostTrigger:   5 * time.Second,
+		SampleRate:    100,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Threshold <= 0 {
+		return fmt.Errorf("threshold must be positive, got %v", c.Threshold)
+	}
+	if c.JerkThreshold <= 0 {
+		return fmt.Errorf("jerk threshold must be positive, got %v", c.JerkThreshold)
+	}
+	if c.PreTrigger < 0 || c.PostTrigger < 0 {
+		return fmt.Errorf("pre and post trigger durations must be positive")
+	}
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %v", c.SampleRate)
+	}
+	return nil
+}
+
+// Event is the immutable record of an impact. Samples cover PreTrigger before
+// the trigger to PostTrigger after it.
+type Event struct {
+	trigger         time.Time
+	peakTime        time.Time
+	peak            [3]float64
+	peakJerk        float64
+	peakAngularRate [3]float64
+	samples         []iim42652.CameraSample
+}
+
+func (e *Event) TriggerTime() time.Time {
+	return e.trigger
+}
+
+func (e *Event) PeakTime() time.Time {
+	return e.peakTime
+}
+
+// PeakAcceleration returns the acceleration vector (g, vehicle frame) with
+// the largest magnitude.
+func (e *Event) PeakAcceleration() [3]float64 {
+	return e.peak
+}
+
+func (e *Event) PeakMagnitude() float64 {
+	return magnitude(e.peak)
+}
+
+// PeakJerk returns the largest jerk magnitude, in g/s.
+func (e *Event) PeakJerk() float64 {
+	return e.peakJerk
+}
+
+// Direction returns where the impact came from, in degrees in the horizontal
+// plane: 0 is the front, 90 the left side, 180 the rear and -90 the right
+// side. The vehicle accelerates away from the impact, so this is the
+// opposite of the peak acceleration. It is 0 when the peak has no horizontal
+// component.
+func (e *Event) Direction() float64 {
+	if e.peak[0] == 0 && e.peak[1] == 0 {
+		return 0
+	}
+	return math.Atan2(-e.peak[1], -e.peak[0]) * 180 / math.Pi
+}
+
+// PeakAngularRate returns the angular rate vector (dps, vehicle frame) with
+// the largest magnitude.
+func (e *Event) PeakAngularRate() [3]float64 {
+	return e.peakAngularRate
+}
+
+// Samples returns a copy of the captured samples.
+func (e *Event) Samples() []iim42652.CameraSample {
+	return append([]iim42652.CameraSample(nil), e.samples...)
+}
+
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TriggerTime      time.Time               `json:"trigger_time"`
+		PeakTime         time.Time               `json:"peak_time"`
+		PeakAcceleration [3]float64              `json:"peak_acceleration"`
+		PeakMagnitude    float64                 `json:"peak_magnitude"`
+		PeakJerk         float64                 `json:"peak_jerk"`
+		Direction        float64                 `json:"direction"`
+		PeakAngularRate  [3]float64              `json:"peak_angular_rate"`
+		Samples          []iim42652.CameraSample `json:"samples"`
+	}{e.trigger, e.peakTime, e.peak, e.PeakMagnitude(), e.peakJerk, e.Direction(), e.peakAngularRate, e.samples})
+}
+
+// Detector watches camera frame samples for impacts. It keeps the recent
+// samples in a ring buffer so that the record of an impact includes what
+// happened before the trigger.
+type Detector struct {
+	config   Config
+	buffer   *ring
+	previous *iim42652.CameraSample
+
+	capture *Event
+}
+
+func NewDetector(config Config) (*Detector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	capacity := int(math.Ceil(config.PreTrigger.Seconds()*config.SampleRate)) + 1
+	return &Detector{
+		config: config,
+		buffer: newRing(capacity),
+	}, nil
+}
+
+// Capturing reports whether an impact was triggered and its post-trigger
+// samples are being captured.
+func (d *Detector) Capturing() bool {
+	return d.capture != nil
+}
+
+// Process feeds one sample and returns the impact event once its capture is
+// complete.
+func (d *Detector) Process(sample *iim42652.CameraSample) *Event {
+	jerk := 0.0
+	if d.previous != nil {
+		if dt := sample.Time.Sub(d.previous.Time).Seconds(); dt > 0 {
+			jerk = magnitude(sub(sample.Acceleration, d.previous.Acceleration)) / dt
+		}
+	}
+	d.previous = sample
+
+	if d.capture == nil {
+		d.buffer.push(*sample)
+		if magnitude(sample.Acceleration) < d.config.Threshold && jerk < d.config.JerkThreshold {
+			return nil
+		}
+
+		d.capture = &Event{trigger: sample.Time}
+		for _, buffered := range d.buffer.snapshot() {
+			if sample.Time.Sub(buffered.Time) <= d.config.PreTrigger {
+				d.capture.samples = append(d.capture.samples, buffered)
+			}
+		}
+		d.buffer.reset()
+	} else {
+		d.capture.samples = append(d.capture.samples, *sample)
+	}
+
+	d.capture.update(sample, jerk)
+	if sample.Time.Sub(d.capture.trigger) < d.config.PostTrigger {
+		return nil
+	}
+	return d.Flush()
+}
+
+// Flush returns the impact being captured, if any, with the samples captured
+// so far.
+func (d *Detector) Flush() *Event {
+	event := d.capture
+	d.capture = nil
+	return event
+}
+
+func (e *Event) update(sample *iim42652.CameraSample, jerk float64) {
+	if magnitude(sample.Acceleration) > magnitude(e.peak) {
+		e.peak = sample.Acceleration
+		e.peakTime = sample.Time
+	}
+	if jerk > e.peakJerk {
+		e.peakJerk = jerk
+	}
+	if magnitude(sample.AngularRate) > magnitude(e.peakAngularRate) {
+		e.peakAngularRate = sample.AngularRate
+	}
+}
+
+func magnitude(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
+
+func sub(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
@@ -0,0 +1,144 @@
+package impact
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Detector(t *testing.T) {
+	tests := []struct {
+		name              string
+		acceleration      func(n int) [3]float64
+		count             int
+		expectedTrigger   int
+		expectedSamples   int
+		expectedDirection float64
+		expectedMagnitude float64
+		expectNoEvent     bool
+	}{
+		{
+			name:          "normal driving",
+			acceleration:  func(n int) [3]float64 { return [3]float64{0.3, -0.2, 1} },
+			count:         2000,
+			expectNoEvent: true,
+		},
+		{
+			name: "frontal collision",
+			acceleration: func(n int) [3]float64 {
+				if n >= 700 && n < 703 {
+					return [3]float64{-5, 0, 1}
+				}
+				return [3]float64{0, 0, 1}
+			},
+			count:             2000,
+			expectedTrigger:   700,
+			expectedSamples:   1001,
+			expectedDirection: 0,
+			expectedMagnitude: 5.0990195,
+		},
+		{
+			name: "side collision from the right",
+			acceleration: func(n int) [3]float64 {
+				if n == 300 {
+					return [3]float64{0, 4, 1}
+				}
+				return [3]float64{0, 0, 1}
+			},
+			count:             2000,
+			expectedTrigger:   300,
+			expectedSamples:   801,
+			expectedDirection: -90,
+			expectedMagnitude: 4.1231056,
+		},
+		{
+			name: "early trigger with a short history",
+			acceleration: func(n int) [3]float64 {
+				if n == 100 {
+					return [3]float64{0, 0, 4}
+				}
+				return [3]float64{0, 0, 1}
+			},
+			count:             2000,
+			expectedTrigger:   100,
+			expectedSamples:   601,
+			expectedMagnitude: 4,
+		},
+		{
+			name: "flushed at the end of the stream",
+			acceleration: func(n int) [3]float64 {
+				if n == 900 {
+					return [3]float64{-4, 0, 1}
+				}
+				return [3]float64{0, 0, 1}
+			},
+			count:             1000,
+			expectedTrigger:   900,
+			expectedSamples:   600,
+			expectedMagnitude: 4.1231056,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector, err := NewDetector(DefaultConfig())
+			require.NoError(t, err)
+
+			var events []*Event
+			for n := 0; n < test.count; n++ {
+				if event := detector.Process(&iim42652.CameraSample{
+					Time:         at(n),
+					Acceleration: test.acceleration(n),
+					AngularRate:  [3]float64{0, 0, float64(n % 7)},
+				}); event != nil {
+					events = append(events, event)
+				}
+			}
+			if event := detector.Flush(); event != nil {
+				events = append(events, event)
+			}
+
+			if test.expectNoEvent {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			event := events[0]
+
+			assert.Equal(t, at(test.expectedTrigger), event.TriggerTime())
+			assert.InDelta(t, test.expectedMagnitude, event.PeakMagnitude(), 1e-6)
+			assert.InDelta(t, test.expectedDirection, event.Direction(), 1e-6)
+			assert.Equal(t, [3]float64{0, 0, 6}, event.PeakAngularRate())
+
+			samples := event.Samples()
+			require.Len(t, samples, test.expectedSamples)
+			assert.False(t, samples[0].Time.Before(at(test.expectedTrigger).Add(-5*time.Second)))
+
+			samples[0].Acceleration[0] = 42
+			assert.NotEqual(t, 42.0, event.Samples()[0].Acceleration[0])
+
+			_, err = json.Marshal(event)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_DetectorJerk(t *testing.T) {
+	detector, err := NewDetector(DefaultConfig())
+	require.NoError(t, err)
+
+	detector.Process(&iim42652.CameraSample{Time: at(0), Acceleration: [3]float64{0, 0, 1}})
+	detector.Process(&iim42652.CameraSample{Time: at(1), Acceleration: [3]float64{2.5, 0, 1}})
+	assert.False(t, detector.Capturing())
+
+	detector.Process(&iim42652.CameraSample{Time: at(2), Acceleration: [3]float64{-2.5, 0, 1}})
+	assert.True(t, detector.Capturing())
+}
+
+func at(n int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond)
+}
@@ -0,0 +1,37 @@
+package impact
+
+import "github.com/streamingfast/imu-controller/device/iim42652"
+
+// Fixed capacity buffer keeping the most recent samples.
+type ring struct {
+	samples []iim42652.CameraSample
+	next    int
+	full    bool
+}
+
+func newRing(capacity int) *ring {
+	return &ring{samples: make([]iim42652.CameraSample, capacity)}
+}
+
+func (r *ring) push(sample iim42652.CameraSample) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Returns a copy of the samples, oldest first.
+func (r *ring) snapshot() []iim42652.CameraSample {
+	if !r.full {
+		return append([]iim42652.CameraSample(nil), r.samples[:r.next]...)
+	}
+	out := make([]iim42652.CameraSample, 0, len(r.samples))
+	out = append(out, r.samples[r.next:]...)
+	return append(out, r.samples[:r.next]...)
+}
+
+func (r *ring) reset() {
+	r.next = 0
+	r.full = false
+}
@@ -0,0 +1,226 @@
+package motion
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+type State int
+
+const (
+	StateUnknown State = iota
+	StateMoving
+	StateStationary
+	StateParked
+)
+
+func (s State) String() string {
+	switch s {
+	case StateMoving:
+		return "moving"
+	case StateStationary:
+		return "stationary"
+	case StateParked:
+		return "parked"
+	default:
+		return "unknown"
+	}
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *State) UnmarshalText(text []byte) error {
+	for _, state := range []State{StateUnknown, StateMoving, StateStationary, StateParked} {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown motion state %q", text)
+}
+
+type StateChange struct {
+	Time time.Time `json:"time"`
+	From State     `json:"from"`
+	To   State     `json:"to"`
+}
+
+func (c *StateChange) String() string {
+	return fmt.Sprintf("StateChange{%s -> %s at %s}", c.From, c.To, c.Time.Format(time.RFC3339Nano))
+}
+
+// Config of the classifier. The moving and stationary thresholds form a
+// hysteresis band: windows between the two keep the current trend.
+type Config struct {
+	// Number of samples per classification window.
+	WindowSize int
+	// Standard deviation (g) of the acceleration magnitude over a window.
+	MovingAccelerationStdDev     float64
+	StationaryAccelerationStdDev float64
+	// Mean angular rate magnitude (dps) over a window.
+	MovingAngularRate     float64
+	StationaryAngularRate float64
+	// Time a trend must last before the state changes.
+	MovingDwell     time.Duration
+	StationaryDwell time.Duration
+	// Time spent stationary before being parked.
+	ParkedDwell time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		WindowSize:                   50,
+		MovingAccelerationStdDev:     0.03,
+		StationaryAccelerationStdDev: 0.015,
+		MovingAngularRate:            3,
+		StationaryAngularRate:        1.5,
+		MovingDwell:                  time.Second,
+		StationaryDwell:              3 * time.Second,
+		ParkedDwell:                  5 * time.Minute,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.WindowSize < 2 {
+		return fmt.Errorf("window size must be at least 2, got %d", c.WindowSize)
+	}
+	if c.StationaryAccelerationStdDev > c.MovingAccelerationStdDev {
+		return fmt.Errorf("stationary acceleration threshold must not exceed the moving one")
+	}
+	if c.StationaryAngularRate > c.MovingAngularRate {
+		return fmt.Errorf("stationary angular rate threshold must not exceed the moving one")
+	}
+	if c.MovingDwell < 0 || c.StationaryDwell < 0 || c.ParkedDwell < 0 {
+		return fmt.Errorf("dwell times must be positive")
+	}
+	return nil
+}
+
+type trend int
+
+const (
+	trendNone trend = iota
+	trendMoving
+	trendStill
+)
+
+// Classifier decides whether the vehicle is moving, stationary (stopped for
+// a while) or parked (stopped for a long time), from the acceleration
+// variance and the angular rate of the samples, and optionally from the
+// motion interrupts of the sensor.
+type Classifier struct {
+	config Config
+
+	count          int
+	magnitudeSum   float64
+	magnitudeSumSq float64
+	angularSum     float64
+	angularCount   int
+
+	state      State
+	stateSince time.Time
+	trend      trend
+	trendSince time.Time
+}
+
+func NewClassifier(config Config) (*Classifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Classifier{config: config}, nil
+}
+
+func (c *Classifier) State() State {
+	return c.state
+}
+
+// Process feeds one sample and returns the state change it caused, if any.
+// The angular rate of samples read while the gyroscope was off, in low power
+// mode, is left out and the window is classified from the acceleration.
+func (c *Classifier) Process(sample *iim42652.CameraSample) *StateChange {
+	accelMagnitude := magnitude(sample.Acceleration)
+	c.magnitudeSum += accelMagnitude
+	c.magnitudeSumSq += accelMagnitude * accelMagnitude
+	if !sample.InvalidAngularRate {
+		c.angularSum += magnitude(sample.AngularRate)
+		c.angularCount++
+	}
+	c.count++
+
+	if c.count < c.config.WindowSize {
+		return nil
+	}
+
+	n := float64(c.count)
+	mean := c.magnitudeSum / n
+	stdDev := math.Sqrt(math.Max(c.magnitudeSumSq/n-mean*mean, 0))
+	angularRate := 0.0
+	if c.angularCount > 0 {
+		angularRate = c.angularSum / float64(c.angularCount)
+	}
+	c.count = 0
+	c.magnitudeSum = 0
+	c.magnitudeSumSq = 0
+	c.angularSum = 0
+	c.angularCount = 0
+
+	observed := trendNone
+	switch {
+	case stdDev > c.config.MovingAccelerationStdDev || angularRate > c.config.MovingAngularRate:
+		observed = trendMoving
+	case stdDev < c.config.StationaryAccelerationStdDev && angularRate < c.config.StationaryAngularRate:
+		observed = trendStill
+	}
+
+	return c.observe(sample.Time, observed)
+}
+
+// ProcessMotionInterrupt reports a motion interrupt raised by the sensor
+// (see IIM42652.ReadMotionInterrupts). The significant motion detection
+// already debounces motion, so the vehicle is moving right away.
+func (c *Classifier) ProcessMotionInterrupt(t time.Time) *StateChange {
+	c.trend = trendMoving
+	c.trendSince = t
+	return c.transition(t, StateMoving)
+}
+
+func (c *Classifier) observe(t time.Time, observed trend) *StateChange {
+	if observed != trendNone && observed != c.trend {
+		c.trend = observed
+		c.trendSince = t
+	}
+
+	switch {
+	case c.state == StateUnknown && c.trend == trendMoving:
+		return c.transition(t, StateMoving)
+	case c.state == StateUnknown && c.trend == trendStill:
+		return c.transition(t, StateStationary)
+	case c.state != StateMoving && c.trend == trendMoving && t.Sub(c.trendSince) >= c.config.MovingDwell:
+		return c.transition(t, StateMoving)
+	case c.state == StateMoving && c.trend == trendStill && t.Sub(c.trendSince) >= c.config.StationaryDwell:
+		return c.transition(t, StateStationary)
+	case c.state == StateStationary && c.trend == trendStill && t.Sub(c.stateSince) >= c.config.ParkedDwell:
+		return c.transition(t, StateParked)
+	}
+	return nil
+}
+
+func (c *Classifier) transition(t time.Time, to State) *StateChange {
+	if c.state == to {
+		return nil
+	}
+
+	change := &StateChange{Time: t, From: c.state, To: to}
+	c.state = to
+	c.stateSince = t
+	return change
+}
+
+func magnitude(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
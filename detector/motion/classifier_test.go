@@ -0,0 +1,136 @@
+package motion
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type phase struct {
+	duration time.Duration
+	moving   bool
+}
+
+func Test_Classifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		phases   []phase
+		expected []StateChange
+	}{
+		{
+			name:   "parked from the start",
+			phases: []phase{{duration: 6 * time.Minute}},
+			expected: []StateChange{
+				{Time: at(49), From: StateUnknown, To: StateStationary},
+				{Time: at(30049), From: StateStationary, To: StateParked},
+			},
+		},
+		{
+			name:   "stop at a light",
+			phases: []phase{{duration: 10 * time.Second, moving: true}, {duration: 30 * time.Second}, {duration: 10 * time.Second, moving: true}},
+			expected: []StateChange{
+				{Time: at(49), From: StateUnknown, To: StateMoving},
+				{Time: at(1349), From: StateMoving, To: StateStationary},
+				{Time: at(4149), From: StateStationary, To: StateMoving},
+			},
+		},
+		{
+			name:   "short stop is ignored",
+			phases: []phase{{duration: 10 * time.Second, moving: true}, {duration: 2 * time.Second}, {duration: 10 * time.Second, moving: true}},
+			expected: []StateChange{
+				{Time: at(49), From: StateUnknown, To: StateMoving},
+			},
+		},
+		{
+			name:   "leaving the parking",
+			phases: []phase{{duration: 6 * time.Minute}, {duration: 5 * time.Second, moving: true}},
+			expected: []StateChange{
+				{Time: at(49), From: StateUnknown, To: StateStationary},
+				{Time: at(30049), From: StateStationary, To: StateParked},
+				{Time: at(36149), From: StateParked, To: StateMoving},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			classifier, err := NewClassifier(DefaultConfig())
+			require.NoError(t, err)
+
+			var changes []StateChange
+			n := 0
+			for _, p := range test.phases {
+				for end := n + int(p.duration/(10*time.Millisecond)); n < end; n++ {
+					sample := &iim42652.CameraSample{Time: at(n), Acceleration: [3]float64{0, 0, 1}}
+					if p.moving {
+						sample.Acceleration[0] = 0.1 * math.Sin(float64(n)/3)
+						sample.AngularRate[2] = 5
+					}
+					if change := classifier.Process(sample); change != nil {
+						changes = append(changes, *change)
+					}
+				}
+			}
+
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
+
+type fakePowerController struct {
+	calls []string
+}
+
+func (f *fakePowerController) EnterLowPowerMode() error {
+	f.calls = append(f.calls, "enter")
+	return nil
+}
+
+func (f *fakePowerController) ExitLowPowerMode() error {
+	f.calls = append(f.calls, "exit")
+	return nil
+}
+
+func Test_PowerManager(t *testing.T) {
+	controller := &fakePowerController{}
+	manager := NewPowerManager(controller)
+
+	classifier, err := NewClassifier(DefaultConfig())
+	require.NoError(t, err)
+
+	for n := 0; n < 31000; n++ {
+		if change := classifier.Process(&iim42652.CameraSample{Time: at(n), Acceleration: [3]float64{0, 0, 1}}); change != nil {
+			require.NoError(t, manager.Handle(change))
+		}
+	}
+	assert.Equal(t, StateParked, classifier.State())
+	assert.True(t, manager.LowPower())
+
+	// The gyroscope is off, its invalid data must not wake the vehicle up.
+	for n := 31000; n < 32000; n++ {
+		sample := &iim42652.CameraSample{
+			Time:               at(n),
+			Acceleration:       [3]float64{0, 0, 1},
+			AngularRate:        [3]float64{2000, 2000, 2000},
+			InvalidAngularRate: true,
+		}
+		assert.Nil(t, classifier.Process(sample))
+	}
+	assert.Equal(t, StateParked, classifier.State())
+
+	change := classifier.ProcessMotionInterrupt(at(32000))
+	require.NotNil(t, change)
+	require.NoError(t, manager.Handle(change))
+
+	assert.Equal(t, StateMoving, classifier.State())
+	assert.False(t, manager.LowPower())
+	assert.Equal(t, []string{"enter", "exit"}, controller.calls)
+}
+
+func at(n int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond)
+}
@@ -0,0 +1,42 @@
+package motion
+
+import "fmt"
+
+// PowerController is implemented by *iim42652.IIM42652.
+type PowerController interface {
+	EnterLowPowerMode() error
+	ExitLowPowerMode() error
+}
+
+// PowerManager switches the sensor to low power mode while the vehicle is
+// parked, and back to low noise mode when it leaves the parked state. While
+// in low power mode the gyroscope is off and the significant motion
+// detection is what wakes the vehicle up, see Classifier.ProcessMotionInterrupt.
+type PowerManager struct {
+	controller PowerController
+	lowPower   bool
+}
+
+func NewPowerManager(controller PowerController) *PowerManager {
+	return &PowerManager{controller: controller}
+}
+
+func (p *PowerManager) LowPower() bool {
+	return p.lowPower
+}
+
+func (p *PowerManager) Handle(change *StateChange) error {
+	switch {
+	case change.To == StateParked && !p.lowPower:
+		if err := p.controller.EnterLowPowerMode(); err != nil {
+			return fmt.Errorf("entering low power mode: %w", err)
+		}
+		p.lowPower = true
+	case change.To != StateParked && p.lowPower:
+		if err := p.controller.ExitLowPowerMode(); err != nil {
+			return fmt.Errorf("exiting low power mode: %w", err)
+		}
+		p.lowPower = false
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package road
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/fusion"
+)
+
+type Config struct {
+	// Vertical acceleration (g) over which a sample is part of an impact.
+	ImpactAccelerationThreshold float64
+	// Vertical jerk (g/s) over which a sample is part of an impact.
+	ImpactJerkThreshold float64
+	// Quiet time after which an impact is over. Samples crossing a threshold
+	// within this delay belong to the same impact.
+	ImpactHoldoff time.Duration
+	// Length of the roughness windows.
+	RoughnessWindow time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		ImpactAccelerationThreshold: 0.5,
+		ImpactJerkThreshold:         50,
+		ImpactHoldoff:               200 * time.Millisecond,
+		RoughnessWindow:             time.Second,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.ImpactAccelerationThreshold <= 0 {
+		return fmt.Errorf("impact acceleration threshold must be positive, got %v", c.ImpactAccelerationThreshold)
+	}
+	if c.ImpactJerkThreshold <= 0 {
+		return fmt.Errorf("impact jerk threshold must be positive, got %v", c.ImpactJerkThreshold)
+	}
+	if c.ImpactHoldoff <= 0 {
+		return fmt.Errorf("impact holdoff must be positive, got %s", c.ImpactHoldoff)
+	}
+	if c.RoughnessWindow <= 0 {
+		return fmt.Errorf("roughness window must be positive, got %s", c.RoughnessWindow)
+	}
+	return nil
+}
+
+// Impact is a discrete shock on the vertical axis, a pothole or a bump.
+// Peaks are signed, in g and g/s.
+type Impact struct {
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	PeakAcceleration float64   `json:"peak_acceleration"`
+	PeakJerk         float64   `json:"peak_jerk"`
+}
+
+// Roughness summarizes the vertical acceleration over a window. RMS is the
+// roughness index, in g.
+type Roughness struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Samples int       `json:"samples"`
+	RMS     float64   `json:"rms"`
+	Peak    float64   `json:"peak"`
+	Impacts int       `json:"impacts"`
+}
+
+// Analyzer detects impacts and computes the roughness index from the
+// vertical linear acceleration (camera frame Z axis, gravity removed).
+type Analyzer struct {
+	config Config
+
+	previous *fusion.LinearAcceleration
+	impact   *Impact
+	lastOver time.Time
+
+	window *Roughness
+	sumSq  float64
+}
+
+func NewAnalyzer(config Config) (*Analyzer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Analyzer{config: config}, nil
+}
+
+// Process feeds one sample. It returns the impact and the roughness window
+// it completed, both are nil most of the time.
+func (a *Analyzer) Process(acceleration *fusion.LinearAcceleration) (*Impact, *Roughness) {
+	var completedImpact *Impact
+	var completedWindow *Roughness
+
+	if a.window != nil && acceleration.Time.Sub(a.window.Start) >= a.config.RoughnessWindow {
+		completedWindow = a.closeWindow()
+	}
+	if a.window == nil {
+		a.window = &Roughness{Start: acceleration.Time}
+		a.sumSq = 0
+	}
+
+	vertical := acceleration.Vertical
+	a.window.Samples++
+	a.window.End = acceleration.Time
+	a.sumSq += vertical * vertical
+	if math.Abs(vertical) > math.Abs(a.window.Peak) {
+		a.window.Peak = vertical
+	}
+
+	jerk := 0.0
+	if a.previous != nil {
+		if dt := acceleration.Time.Sub(a.previous.Time).Seconds(); dt > 0 {
+			jerk = (vertical - a.previous.Vertical) / dt
+		}
+	}
+	a.previous = acceleration
+
+	if a.impact != nil && acceleration.Time.Sub(a.lastOver) > a.config.ImpactHoldoff {
+		completedImpact = a.impact
+		a.impact = nil
+	}
+
+	if math.Abs(vertical) >= a.config.ImpactAccelerationThreshold || math.Abs(jerk) >= a.config.ImpactJerkThreshold {
+		if a.impact == nil {
+			a.impact = &Impact{Start: acceleration.Time}
+			a.window.Impacts++
+		}
+		a.impact.End = acceleration.Time
+		a.lastOver = acceleration.Time
+		if math.Abs(vertical) > math.Abs(a.impact.PeakAcceleration) {
+			a.impact.PeakAcceleration = vertical
+		}
+		if math.Abs(jerk) > math.Abs(a.impact.PeakJerk) {
+			a.impact.PeakJerk = jerk
+		}
+	}
+
+	return completedImpact, completedWindow
+}
+
+// Flush returns the impact in progress and the current, partial, roughness
+// window, typically when the stream ends.
+func (a *Analyzer) Flush() (*Impact, *Roughness) {
+	impact := a.impact
+	a.impact = nil
+	a.previous = nil
+
+	var window *Roughness
+	if a.window != nil {
+		window = a.closeWindow()
+	}
+	return impact, window
+}
+
+func (a *Analyzer) closeWindow() *Roughness {
+	window := a.window
+	window.RMS = math.Sqrt(a.sumSq / float64(window.Samples))
+	a.window = nil
+	return window
+}
@@ -0,0 +1,115 @@
+package road
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/fusion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Analyzer(t *testing.T) {
+	tests := []struct {
+		name            string
+		vertical        func(n int) float64
+		count           int
+		expectedImpacts []*Impact
+		expectedRMS     []float64
+	}{
+		{
+			name:        "smooth road",
+			vertical:    func(n int) float64 { return 0 },
+			count:       250,
+			expectedRMS: []float64{0, 0, 0},
+		},
+		{
+			name: "rough road",
+			vertical: func(n int) float64 {
+				return 0.2 * math.Sin(float64(n)*math.Pi/4)
+			},
+			count:       200,
+			expectedRMS: []float64{0.2 / math.Sqrt2, 0.2 / math.Sqrt2},
+		},
+		{
+			name: "pothole",
+			vertical: func(n int) float64 {
+				switch n {
+				case 50:
+					return -0.8
+				case 51:
+					return 0.6
+				case 52:
+					return 0.1
+				}
+				return 0
+			},
+			count: 200,
+			expectedImpacts: []*Impact{
+				{Start: at(50), End: at(52), PeakAcceleration: -0.8, PeakJerk: 140},
+			},
+			expectedRMS: []float64{math.Sqrt((0.64 + 0.36 + 0.01) / 100), 0},
+		},
+		{
+			name: "two bumps",
+			vertical: func(n int) float64 {
+				if n == 20 || n == 80 {
+					return 0.4
+				}
+				return 0
+			},
+			count: 100,
+			expectedImpacts: []*Impact{
+				{Start: at(20), End: at(21), PeakAcceleration: 0.4, PeakJerk: 40},
+				{Start: at(80), End: at(81), PeakAcceleration: 0.4, PeakJerk: 40},
+			},
+			expectedRMS: []float64{math.Sqrt(0.32 / 100)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.ImpactJerkThreshold = 30
+			analyzer, err := NewAnalyzer(config)
+			require.NoError(t, err)
+
+			var impacts []*Impact
+			var windows []*Roughness
+			collect := func(impact *Impact, window *Roughness) {
+				if impact != nil {
+					impacts = append(impacts, impact)
+				}
+				if window != nil {
+					windows = append(windows, window)
+				}
+			}
+
+			for n := 0; n < test.count; n++ {
+				collect(analyzer.Process(&fusion.LinearAcceleration{Time: at(n), Vertical: test.vertical(n)}))
+			}
+			collect(analyzer.Flush())
+
+			require.Len(t, impacts, len(test.expectedImpacts))
+			for idx, expected := range test.expectedImpacts {
+				assert.Equal(t, expected.Start, impacts[idx].Start)
+				assert.Equal(t, expected.End, impacts[idx].End)
+				assert.InDelta(t, expected.PeakAcceleration, impacts[idx].PeakAcceleration, 1e-9)
+				assert.InDelta(t, expected.PeakJerk, impacts[idx].PeakJerk, 1e-6)
+			}
+
+			require.Len(t, windows, len(test.expectedRMS))
+			total := 0
+			for idx, expected := range test.expectedRMS {
+				assert.InDelta(t, expected, windows[idx].RMS, 1e-3)
+				total += windows[idx].Impacts
+			}
+			assert.Equal(t, len(test.expectedImpacts), total)
+		})
+	}
+}
+
+func at(n int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond)
+}
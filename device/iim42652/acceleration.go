@@ -40,8 +40,10 @@ func NewAcceleration(x, y, z int16, sensitivity AccelerationSensitivity) *Accele
 	return accel
 }
 
+// String prints the values along the IMU axes, use a MountTransform for the
+// camera frame.
 func (a *Acceleration) String() string {
-	return fmt.Sprintf("Acceleration{camX:%.5f, camY:%.5f, camZ: %.5f, totalMagn: %.5f}", a.Z, a.X, a.Y, a.TotalMagnitude)
+	return fmt.Sprintf("Acceleration{imuX:%.5f, imuY:%.5f, imuZ:%.5f, totalMagn:%.5f}", a.X, a.Y, a.Z, a.TotalMagnitude)
 }
 
 func (a *Acceleration) CamX() float64 {
@@ -97,7 +99,7 @@ func (i *IIM42652) SetupSignificantMotionDetection() error {
 	}
 
 	err := i.UpdateRegister(RegisterPwrMgmt0, func(currentValue byte) byte {
-		fmt.Println("currentValue", currentValue, "AccelerometerModeLowPower", AccelerometerModeLowPower, "currentValue | AccelerometerModeLowPower", currentValue|AccelerometerModeLowPower)
+		fmt.Fprintln(i.logOutput, "currentValue", currentValue, "AccelerometerModeLowPower", AccelerometerModeLowPower, "currentValue | AccelerometerModeLowPower", currentValue|AccelerometerModeLowPower)
 		return currentValue | AccelerometerModeLowPower
 	})
 	if err != nil {
@@ -160,6 +162,41 @@ func (i *IIM42652) SetupSignificantMotionDetection() error {
 	return nil
 }
 
+// MotionInterrupts holds the motion interrupt flags of INT_STATUS2.
+type MotionInterrupts struct {
+	SignificantMotion bool
+	WakeOnMotionX     bool
+	WakeOnMotionY     bool
+	WakeOnMotionZ     bool
+}
+
+func (m MotionInterrupts) Any() bool {
+	return m.SignificantMotion || m.WakeOnMotionX || m.WakeOnMotionY || m.WakeOnMotionZ
+}
+
+const (
+	bitIntStatus2SmdInt  byte = 0x08
+	bitIntStatus2WomZInt byte = 0x04
+	bitIntStatus2WomYInt byte = 0x02
+	bitIntStatus2WomXInt byte = 0x01
+)
+
+// ReadMotionInterrupts reads the motion interrupts raised by the significant
+// motion detection. Reading INT_STATUS2 clears them.
+func (i *IIM42652) ReadMotionInterrupts() (MotionInterrupts, error) {
+	status, err := i.ReadRegister(RegisterIntStatus2)
+	if err != nil {
+		return MotionInterrupts{}, fmt.Errorf("reading RegisterIntStatus2 %q: %w", RegisterIntStatus2, err)
+	}
+
+	return MotionInterrupts{
+		SignificantMotion: status&bitIntStatus2SmdInt != 0,
+		WakeOnMotionX:     status&bitIntStatus2WomXInt != 0,
+		WakeOnMotionY:     status&bitIntStatus2WomYInt != 0,
+		WakeOnMotionZ:     status&bitIntStatus2WomZInt != 0,
+	}, nil
+}
+
 func (i *IIM42652) GetAcceleration() (*Acceleration, error) {
 	i.registerLock.Lock()
 	defer i.registerLock.Unlock()
@@ -172,7 +209,7 @@ func (i *IIM42652) GetAcceleration() (*Acceleration, error) {
 	msg := make([]byte, 7)
 	result := make([]byte, 7)
 	msg[0] = ReadMask | byte(RegisterAccelDataX1.Address)
-	if err := i.connection.Tx(msg, result); err != nil {
+	if err := i.tx(RegisterAccelDataX1, false, msg, result); err != nil {
 		return nil, fmt.Errorf("reading to SPI port: %w", err)
 	}
 
@@ -1,7 +1,33 @@
 package iim42652
 
+import (
+	"fmt"
+	"math"
+)
+
 type ImuAxis string
 
+const (
+	ImuAxisX ImuAxis = "X"
+	ImuAxisY ImuAxis = "Y"
+	ImuAxisZ ImuAxis = "Z"
+)
+
+func (a ImuAxis) valid() bool {
+	return a == ImuAxisX || a == ImuAxisY || a == ImuAxisZ
+}
+
+func (a ImuAxis) index() int {
+	switch a {
+	case ImuAxisX:
+		return 0
+	case ImuAxisY:
+		return 1
+	default:
+		return 2
+	}
+}
+
 type AxisMap struct {
 	CamX ImuAxis
 	CamY ImuAxis
@@ -11,6 +37,8 @@ type AxisMap struct {
 	InvZ bool
 }
 
+// NewAxisMap does not validate the axes, use ParseAxisMap for values coming
+// from the user.
 func NewAxisMap(x, y, z string) *AxisMap {
 	return &AxisMap{
 		CamX: ImuAxis(x),
@@ -19,74 +47,81 @@ func NewAxisMap(x, y, z string) *AxisMap {
 	}
 }
 
+// ParseAxisMap returns an error when an axis is invalid or mapped twice.
+func ParseAxisMap(x, y, z string) (*AxisMap, error) {
+	am := NewAxisMap(x, y, z)
+	if err := am.Validate(); err != nil {
+		return nil, err
+	}
+	return am, nil
+}
+
+// DefaultAxisMap returns the mapping hard-coded in the CamX, CamY and CamZ
+// methods of Acceleration and AngularRate.
+func DefaultAxisMap() *AxisMap {
+	return &AxisMap{
+		CamX: ImuAxisZ,
+		CamY: ImuAxisX,
+		CamZ: ImuAxisY,
+	}
+}
+
 func (am *AxisMap) SetInvertedAxes(invX, invY, invZ bool) {
 	am.InvX = invX
 	am.InvY = invY
 	am.InvZ = invZ
 }
 
-func (am *AxisMap) X(acceleration *Acceleration) float64 {
-	switch am.CamX {
-	case "X":
-		if am.InvX {
-			return -acceleration.X
-		}
-		return acceleration.X
-	case "Y":
-		if am.InvX {
-			return -acceleration.Y
+// Validate checks that every camera axis maps to a distinct IMU axis.
+func (am *AxisMap) Validate() error {
+	axes := []ImuAxis{am.CamX, am.CamY, am.CamZ}
+	for idx, axis := range axes {
+		if !axis.valid() {
+			return fmt.Errorf("invalid axis %q, must be one of X, Y or Z", axis)
 		}
-		return acceleration.Y
-	case "Z":
-		if am.InvX {
-			return -acceleration.Z
+		for _, other := range axes[:idx] {
+			if axis == other {
+				return fmt.Errorf("axis %q is mapped more than once", axis)
+			}
 		}
-		return acceleration.Z
-	default:
-		panic("invalid axis")
 	}
+	return nil
+}
+
+// Transform returns the MountTransform equivalent to the axis map, it can be
+// applied to angular rates and full samples as well as accelerations.
+func (am *AxisMap) Transform() (*MountTransform, error) {
+	return NewMountTransformFromAxisMap(am)
+}
+
+// X, Y and Z return NaN when the axis map is invalid, see Validate.
+func (am *AxisMap) X(acceleration *Acceleration) float64 {
+	return axisValue(am.CamX, am.InvX, acceleration)
 }
 
 func (am *AxisMap) Y(acceleration *Acceleration) float64 {
-	switch am.CamY {
-	case "X":
-		if am.InvY {
-			return -acceleration.X
-		}
-		return acceleration.X
-	case "Y":
-		if am.InvY {
-			return -acceleration.Y
-		}
-		return acceleration.Y
-	case "Z":
-		if am.InvY {
-			return -acceleration.Z
-		}
-		return acceleration.Z
-	default:
-		panic("invalid axis")
-	}
+	return axisValue(am.CamY, am.InvY, acceleration)
 }
 
 func (am *AxisMap) Z(acceleration *Acceleration) float64 {
-	switch am.CamZ {
-	case "X":
-		if am.InvZ {
-			return -acceleration.X
-		}
-		return acceleration.X
-	case "Y":
-		if am.InvZ {
-			return -acceleration.Y
-		}
-		return acceleration.Y
-	case "Z":
-		if am.InvZ {
-			return -acceleration.Z
-		}
-		return acceleration.Z
-	default:
-		panic("invalid axis")
+	return axisValue(am.CamZ, am.InvZ, acceleration)
+}
+
+// Camera returns the acceleration along the camera X, Y and Z axes.
+func (am *AxisMap) Camera(acceleration *Acceleration) ([3]float64, error) {
+	if err := am.Validate(); err != nil {
+		return [3]float64{}, fmt.Errorf("invalid axis map: %w", err)
+	}
+	return [3]float64{am.X(acceleration), am.Y(acceleration), am.Z(acceleration)}, nil
+}
+
+func axisValue(axis ImuAxis, inverted bool, acceleration *Acceleration) float64 {
+	if !axis.valid() {
+		return math.NaN()
+	}
+	value := [3]float64{acceleration.X, acceleration.Y, acceleration.Z}[axis.index()]
+	if inverted {
+		return -value
 	}
+	return value
 }
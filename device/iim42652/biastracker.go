@@ -0,0 +1,208 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+)
+
+// GyroBiasTrackerConfig holds the thresholds used by the GyroBiasTracker to
+// decide if the device is stationary and how fast the bias estimate moves.
+type GyroBiasTrackerConfig struct {
+	// Number of samples in a detection window.
+	WindowSize int
+	// Maximum per axis acceleration variance (g²) for a window to be considered stationary.
+	AccelerationVarianceThreshold float64
+	// Maximum per axis angular rate variance (dps²) for a window to be considered stationary.
+	AngularRateVarianceThreshold float64
+	// Maximum mean angular rate (dps) of a stationary window. A slow, steady turn
+	// has a low variance too, this rejects it.
+	AngularRateMagnitudeThreshold float64
+	// Maximum distance (g) between the mean acceleration magnitude and 1g.
+	GravityTolerance float64
+	// Weight (0-1) given to the mean of a new stationary window when updating the bias.
+	FilterGain float64
+}
+
+func DefaultGyroBiasTrackerConfig() GyroBiasTrackerConfig {
+	return GyroBiasTrackerConfig{
+		WindowSize:                    100,
+		AccelerationVarianceThreshold: 0.0004,
+		AngularRateVarianceThreshold:  0.25,
+		AngularRateMagnitudeThreshold: 3.0,
+		GravityTolerance:              0.05,
+		FilterGain:                    0.05,
+	}
+}
+
+func (c GyroBiasTrackerConfig) Validate() error {
+	if c.WindowSize < 2 {
+		return fmt.Errorf("window size must be at least 2, got %d", c.WindowSize)
+	}
+	if c.FilterGain <= 0 || c.FilterGain > 1 {
+		return fmt.Errorf("filter gain must be in ]0, 1], got %v", c.FilterGain)
+	}
+	return nil
+}
+
+// GyroBiasTracker estimates the gyroscope bias at runtime. Samples are grouped
+// in windows, and every window where both the accelerometer and the gyroscope
+// are quiet is treated as a zero-motion interval: its mean angular rate is the
+// bias, and it is blended into the estimate with a slow exponential filter.
+//
+// The estimate is expressed in dps along the IMU axes, on top of whatever is
+// already programmed in the OFFSET_USER registers. It can be applied in
+// software with Correct, or written to the device with Commit.
+type GyroBiasTracker struct {
+	config GyroBiasTrackerConfig
+
+	count        int
+	accelSum     [3]float64
+	accelSumSq   [3]float64
+	angularSum   [3]float64
+	angularSumSq [3]float64
+
+	bias              [3]float64
+	stationary        bool
+	stationaryWindows int
+}
+
+func NewGyroBiasTracker(config GyroBiasTrackerConfig) (*GyroBiasTracker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &GyroBiasTracker{config: config}, nil
+}
+
+// Update feeds one sample to the tracker. It returns true when the sample
+// completed a stationary window and the bias estimate was updated.
+func (t *GyroBiasTracker) Update(acceleration *Acceleration, angularRate *AngularRate) bool {
+	accel := [3]float64{acceleration.X, acceleration.Y, acceleration.Z}
+	angular := [3]float64{angularRate.X, angularRate.Y, angularRate.Z}
+	for axis := 0; axis < 3; axis++ {
+		t.accelSum[axis] += accel[axis]
+		t.accelSumSq[axis] += accel[axis] * accel[axis]
+		t.angularSum[axis] += angular[axis]
+		t.angularSumSq[axis] += angular[axis] * angular[axis]
+	}
+	t.count++
+
+	if t.count < t.config.WindowSize {
+		return false
+	}
+
+	var accelMean, angularMean [3]float64
+	t.stationary = true
+	n := float64(t.count)
+	for axis := 0; axis < 3; axis++ {
+		accelMean[axis] = t.accelSum[axis] / n
+		angularMean[axis] = t.angularSum[axis] / n
+
+		accelVariance := t.accelSumSq[axis]/n - accelMean[axis]*accelMean[axis]
+		angularVariance := t.angularSumSq[axis]/n - angularMean[axis]*angularMean[axis]
+		if accelVariance > t.config.AccelerationVarianceThreshold || angularVariance > t.config.AngularRateVarianceThreshold {
+			t.stationary = false
+		}
+	}
+	if math.Abs(magnitude(accelMean)-1.0) > t.config.GravityTolerance {
+		t.stationary = false
+	}
+	if magnitude(angularMean) > t.config.AngularRateMagnitudeThreshold {
+		t.stationary = false
+	}
+	t.resetWindow()
+
+	if !t.stationary {
+		return false
+	}
+
+	// The first window is taken as is, the filter only smooths later ones.
+	gain := t.config.FilterGain
+	if t.stationaryWindows == 0 {
+		gain = 1
+	}
+	for axis := 0; axis < 3; axis++ {
+		t.bias[axis] += gain * (angularMean[axis] - t.bias[axis])
+	}
+	t.stationaryWindows++
+	return true
+}
+
+func (t *GyroBiasTracker) resetWindow() {
+	t.count = 0
+	t.accelSum = [3]float64{}
+	t.accelSumSq = [3]float64{}
+	t.angularSum = [3]float64{}
+	t.angularSumSq = [3]float64{}
+}
+
+// Bias returns the current bias estimate in dps along the IMU X, Y and Z axes.
+func (t *GyroBiasTracker) Bias() [3]float64 {
+	return t.bias
+}
+
+// Stationary reports whether the last complete window was a zero-motion interval.
+func (t *GyroBiasTracker) Stationary() bool {
+	return t.stationary
+}
+
+// StationaryWindows returns the number of zero-motion windows that contributed to the estimate.
+func (t *GyroBiasTracker) StationaryWindows() int {
+	return t.stationaryWindows
+}
+
+// Confidence returns a value between 0 and 1 describing how settled the
+// estimate is: the noise variance the filter reaches once settled, divided by
+// the noise variance of the current estimate. The first stationary window is
+// taken with a gain of 1 and keeps a weight of (1-FilterGain)^(n-1) after n
+// windows, later ones are weighted FilterGain*(1-FilterGain)^(n-k).
+func (t *GyroBiasTracker) Confidence() float64 {
+	if t.stationaryWindows == 0 {
+		return 0
+	}
+	gain := t.config.FilterGain
+	settled := gain / (2 - gain)
+	seed := math.Pow(1-gain, 2*float64(t.stationaryWindows-1))
+	return settled / (seed + settled*(1-seed))
+}
+
+// Correct returns a copy of angularRate with the estimated bias removed from
+// the scaled values. Raw values are left untouched.
+func (t *GyroBiasTracker) Correct(angularRate *AngularRate) *AngularRate {
+	return &AngularRate{
+		RawX: angularRate.RawX,
+		RawY: angularRate.RawY,
+		RawZ: angularRate.RawZ,
+		X:    angularRate.X - t.bias[0],
+		Y:    angularRate.Y - t.bias[1],
+		Z:    angularRate.Z - t.bias[2],
+	}
+}
+
+// Commit writes the current estimate to the OFFSET_USER registers of the
+// device. Once written, the device output no longer contains the bias so the
+// estimate is reset to zero while the confidence is kept.
+func (t *GyroBiasTracker) Commit(i *IIM42652) error {
+	if err := i.ApplyGyroBiasCorrection(t.bias); err != nil {
+		return fmt.Errorf("applying gyro bias correction: %w", err)
+	}
+	t.bias = [3]float64{}
+	return nil
+}
+
+// ApplyGyroBiasCorrection removes bias (in dps) from the offsets currently
+// programmed in the gyro OFFSET_USER registers.
+func (i *IIM42652) ApplyGyroBiasCorrection(bias [3]float64) error {
+	offsets, err := i.readGyroOffsetFromUserRegister()
+	if err != nil {
+		return fmt.Errorf("reading gyro offsets: %w", err)
+	}
+
+	for axis := 0; axis < 3; axis++ {
+		offsets[axis], err = clampUserOffset(float64(offsets[axis]) - bias[axis]*gyroOffuserUnitsPerDps)
+		if err != nil {
+			return fmt.Errorf("gyro axis %d: %w", axis, err)
+		}
+	}
+
+	return i.writeGyroOffsetToUserRegister(offsets)
+}
@@ -0,0 +1,114 @@
+package iim42652
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GyroBiasTracker(t *testing.T) {
+	tests := []struct {
+		name               string
+		acceleration       func(n int) *Acceleration
+		angularRate        func(n int) *AngularRate
+		expectedBias       [3]float64
+		expectedStationary bool
+	}{
+		{
+			name: "stationary with bias",
+			acceleration: func(n int) *Acceleration {
+				return &Acceleration{X: 0.001 * math.Sin(float64(n)), Z: 1.0}
+			},
+			angularRate: func(n int) *AngularRate {
+				return &AngularRate{X: 0.5 + 0.1*math.Sin(float64(n)), Y: -0.25, Z: 1.0}
+			},
+			expectedBias:       [3]float64{0.5, -0.25, 1.0},
+			expectedStationary: true,
+		},
+		{
+			name: "vibrating",
+			acceleration: func(n int) *Acceleration {
+				return &Acceleration{X: 0.2 * math.Sin(float64(n)), Z: 1.0}
+			},
+			angularRate: func(n int) *AngularRate {
+				return &AngularRate{X: 0.5}
+			},
+			expectedStationary: false,
+		},
+		{
+			name: "steady turn",
+			acceleration: func(n int) *Acceleration {
+				return &Acceleration{Z: 1.0}
+			},
+			angularRate: func(n int) *AngularRate {
+				return &AngularRate{Z: 15.0}
+			},
+			expectedStationary: false,
+		},
+		{
+			name: "accelerating",
+			acceleration: func(n int) *Acceleration {
+				return &Acceleration{X: 0.4, Z: 1.0}
+			},
+			angularRate: func(n int) *AngularRate {
+				return &AngularRate{X: 0.5}
+			},
+			expectedStationary: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultGyroBiasTrackerConfig()
+			tracker, err := NewGyroBiasTracker(config)
+			require.NoError(t, err)
+
+			for n := 0; n < 10*config.WindowSize; n++ {
+				tracker.Update(test.acceleration(n), test.angularRate(n))
+			}
+
+			assert.Equal(t, test.expectedStationary, tracker.Stationary())
+			for axis := 0; axis < 3; axis++ {
+				assert.InDelta(t, test.expectedBias[axis], tracker.Bias()[axis], 0.01)
+			}
+			if test.expectedStationary {
+				assert.Equal(t, 10, tracker.StationaryWindows())
+				assert.InDelta(t, 0.062, tracker.Confidence(), 0.001)
+
+				corrected := tracker.Correct(&AngularRate{X: 0.5, Y: -0.25, Z: 1.0})
+				assert.InDelta(t, 0.0, corrected.X, 0.01)
+				assert.InDelta(t, 0.0, corrected.Y, 0.01)
+				assert.InDelta(t, 0.0, corrected.Z, 0.01)
+			} else {
+				assert.Equal(t, 0.0, tracker.Confidence())
+			}
+		})
+	}
+}
+
+func Test_OffsetsPacking(t *testing.T) {
+	tests := []struct {
+		name       string
+		offsets    [3]int16
+		sharedByte byte
+	}{
+		{"zero", [3]int16{0, 0, 0}, 0x00},
+		{"positive", [3]int16{1, 256, 2047}, 0x00},
+		{"negative", [3]int16{-1, -256, -2048}, 0x00},
+		{"keeps shared bits", [3]int16{-5, 12, -700}, 0xA5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gyro := packGyroOffsets(test.offsets, test.sharedByte)
+			assert.Equal(t, test.offsets, unpackGyroOffsets(gyro))
+			assert.Equal(t, test.sharedByte&bitAccelXOffuserMaskHi, gyro[4]&bitAccelXOffuserMaskHi, "accelerometer bits must be kept")
+
+			accelerometer := packAccelerometerOffsets(test.offsets, test.sharedByte)
+			assert.Equal(t, test.offsets, unpackAccelerometerOffsets(accelerometer))
+			assert.Equal(t, test.sharedByte&bitGyroZOffuserMaskHi, accelerometer[0]&bitGyroZOffuserMaskHi, "gyro bits must be kept")
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package iim42652
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 )
 
@@ -27,6 +30,21 @@ const (
 	bitGyroUiFiltBandwidth          byte = (0x04 << bitGyroAccelConfig0GyroFiltPos) // BW_10
 )
 
+// Accelerometer configuration register constants.
+const (
+	bitAccelFsSelectPos         byte = 5
+	bitAccelConfig0FSSelectMask byte = (0x7 << bitAccelFsSelectPos)
+)
+
+// Full scale ranges indexed by the FS_SEL value of the configuration registers.
+var (
+	gyroFullScaleRangesDps = []float64{2000, 1000, 500, 250, 125, 62.5, 31.25, 15.625}
+	accelFullScaleRangesG  = []float64{16, 8, 4, 2}
+)
+
+// Raw value matching the full scale range of a sensor.
+const rawFullScale float64 = 32768
+
 // User register constants.
 const (
 	bitGyroXOffuserPosLo  byte = 0
@@ -61,17 +79,37 @@ const (
 )
 
 // Used to convert bias values into a format that can be stored in the
-// user registers.
+// user registers. They hold 12 bits signed values, 1/32 dps for the gyro
+// (±64 dps) and 0.5 mg for the accelerometer (±1 g).
 const (
-	accelOffuserMaxMg        int32 = 1000
-	gyroOffuserMaxDps        int32 = 64
-	gyroOffuserConfiguredDps int32 = 2000
+	gyroOffuserUnitsPerDps float64 = 32
+	accelOffuserUnitsPerG  float64 = 2000
+	offuserMinValue        int32   = -2048
+	offuserMaxValue        int32   = 2047
 )
 
+// ErrUserOffsetOverflow is returned when a bias is too large to be
+// compensated by the user offset registers.
+var ErrUserOffsetOverflow = errors.New("bias exceeds the user offset register range")
+
 ////////////////////////////////////////////////////////////
 /// COMMON FUNCTIONS
 ////////////////////////////////////////////////////////////
 
+// Clamps an offset, in register units, to the 12 bits range of the user
+// registers. An error wrapping ErrUserOffsetOverflow is returned along with
+// the clamped value when it doesn't fit.
+func clampUserOffset(offset float64) (int16, error) {
+	rounded := int32(math.Round(offset))
+	if rounded < offuserMinValue {
+		return int16(offuserMinValue), fmt.Errorf("offset %d is below %d: %w", rounded, offuserMinValue, ErrUserOffsetOverflow)
+	}
+	if rounded > offuserMaxValue {
+		return int16(offuserMaxValue), fmt.Errorf("offset %d is above %d: %w", rounded, offuserMaxValue, ErrUserOffsetOverflow)
+	}
+	return int16(rounded), nil
+}
+
 // Store the low 8 bits of cur_bias
 func storeLowBits(cur_bias int16, offset byte) byte {
 	return byte((cur_bias & 0x00FF) << int16(offset))
@@ -82,22 +120,77 @@ func storeHighBits(cur_bias int16, offset byte) byte {
 	return byte(((cur_bias & 0x0F00) >> 8) << int16(offset))
 }
 
+// Accumulates raw sensor readings. Readings where an axis holds InvalidData
+// are discarded.
+type rawStatistics struct {
+	samples   int32
+	discarded int32
+	sum       [3]int64
+	sumSq     [3]float64
+}
+
+func (s *rawStatistics) add(x, y, z int16) {
+	s.samples++
+	if x == InvalidData || y == InvalidData || z == InvalidData {
+		s.discarded++
+		return
+	}
+
+	for axis, value := range [3]int16{x, y, z} {
+		s.sum[axis] += int64(value)
+		s.sumSq[axis] += float64(value) * float64(value)
+	}
+}
+
+func (s *rawStatistics) valid() int32 {
+	return s.samples - s.discarded
+}
+
+func (s *rawStatistics) average() (average [3]int32, err error) {
+	numSamples := int64(s.valid())
+	if numSamples == 0 {
+		return average, fmt.Errorf("all %d samples were discarded", s.samples)
+	}
+
+	average[0] = int32(s.sum[0] / numSamples)
+	average[1] = int32(s.sum[1] / numSamples)
+	average[2] = int32(s.sum[2] / numSamples)
+	return average, nil
+}
+
+func (s *rawStatistics) mean() (mean [3]float64) {
+	numSamples := float64(s.valid())
+	for axis := 0; axis < 3; axis++ {
+		mean[axis] = float64(s.sum[axis]) / numSamples
+	}
+	return mean
+}
+
+// Population standard deviation of each axis, in raw units.
+func (s *rawStatistics) stdDev() (stdDev [3]float64) {
+	numSamples := float64(s.valid())
+	mean := s.mean()
+	for axis := 0; axis < 3; axis++ {
+		variance := s.sumSq[axis]/numSamples - mean[axis]*mean[axis]
+		stdDev[axis] = math.Sqrt(math.Max(variance, 0))
+	}
+	return stdDev
+}
+
 ////////////////////////////////////////////////////////////
 /// GYRO CALIBRATION
 ////////////////////////////////////////////////////////////
 
-// Initialize gyro with sensible FSR, ODR, and filter values.
+// Initialize gyro with sensible ODR, and filter values.
 // Not clear if this is necessary, but it doesn't hurt.
+// The FSR is left as configured, the bias conversion reads it back.
 func (i *IIM42652) initializeGyroForCalibration() error {
-	// set the ODR to 1khz and FSR to 2000dps (but I think it's already set to this)
+	// set the ODR to 1khz
 	gyroConfig, err := i.ReadRegister(RegisterGyroscopeConfig0)
 	if err != nil {
 		return err
 	}
 
-	gyroConfig &= ^bitGyroConfig0FSSelectMask
-	gyroConfig |= bitGyroFSSelect2000dps
-
 	gyroConfig &= ^bitGyroConfig0ODRMask
 	gyroConfig |= bitGyroODRSelect1KHz
 
@@ -135,68 +228,74 @@ func (i *IIM42652) initializeGyroForCalibration() error {
 }
 
 func (i *IIM42652) AverageGyroSensorOutput(maxSamples int32) (average [3]int32, err error) {
-	numSamples := int32(0)
-	samplesDiscarded := int32(0)
-	sum := [3]int32{0, 0, 0}
-	for {
+	stats, err := i.collectGyroStatistics(maxSamples)
+	if err != nil {
+		return average, err
+	}
+	return stats.average()
+}
+
+func (i *IIM42652) collectGyroStatistics(maxSamples int32) (*rawStatistics, error) {
+	stats := &rawStatistics{}
+	for stats.samples < maxSamples {
 		gyroscopeData, err := i.GetGyroscopeData()
 		if err != nil {
-			return average, err
+			return nil, err
 		}
-
-		if gyroscopeData.RawX != -32768 && gyroscopeData.RawY != -32768 && gyroscopeData.RawZ != -32768 {
-			sum[0] += int32(gyroscopeData.RawX)
-			sum[1] += int32(gyroscopeData.RawY)
-			sum[2] += int32(gyroscopeData.RawZ)
-		} else {
-			samplesDiscarded++
-		}
-
-		numSamples++
+		stats.add(gyroscopeData.RawX, gyroscopeData.RawY, gyroscopeData.RawZ)
 		time.Sleep(time.Millisecond)
-		if numSamples == maxSamples {
-			break
-		}
 	}
+	return stats, nil
+}
 
-	// compute average value
-	numSamples -= samplesDiscarded
-	average[0] = (sum[0] / numSamples)
-	average[1] = (sum[1] / numSamples)
-	average[2] = (sum[2] / numSamples)
-	return average, nil
+// Reads the gyro full scale range, in dps, currently configured on the device.
+func (i *IIM42652) readGyroFullScaleRange() (float64, error) {
+	gyroConfig, err := i.ReadRegister(RegisterGyroscopeConfig0)
+	if err != nil {
+		return 0, err
+	}
+	return gyroFullScaleRange(gyroConfig), nil
+}
+
+func gyroFullScaleRange(gyroConfig byte) float64 {
+	return gyroFullScaleRangesDps[(gyroConfig&bitGyroConfig0FSSelectMask)>>bitGyroFsSelectPos]
 }
 
-// Negate the bias value and onvert the reading to the 64dps equivalent.
-func convertGyroBiasToRegisterFormat(gyroBias int32) int16 {
-	return int16(-(gyroBias * gyroOffuserConfiguredDps / gyroOffuserMaxDps) >> 4)
+// Negate the bias value, read at fullScaleDps, and convert it to
+// the 1/32 dps unit of the user registers.
+func convertGyroBiasToRegisterFormat(gyroBias int32, fullScaleDps float64) (int16, error) {
+	offset, err := clampUserOffset(-float64(gyroBias) * fullScaleDps / rawFullScale * gyroOffuserUnitsPerDps)
+	if err != nil {
+		return offset, fmt.Errorf("gyro bias of %d at %v dps: %w", gyroBias, fullScaleDps, err)
+	}
+	return offset, nil
 }
 
 func (i *IIM42652) writeGyroBiasToUserRegister(bias [3]int32) error {
-	// The 3 bias values are stored as 12 bits each.
-	// They need to be stored interleaved across 5 byte registers
-	// Look at the IIM42652 datasheet for more info.
-	data := [5]byte{0, 0, 0, 0, 0}
+	fullScale, err := i.readGyroFullScaleRange()
+	if err != nil {
+		return fmt.Errorf("reading gyro full scale range: %w", err)
+	}
+
+	var offsets [3]int16
+	for axis := 0; axis < 3; axis++ {
+		offsets[axis], err = convertGyroBiasToRegisterFormat(bias[axis], fullScale)
+		if err != nil {
+			return err
+		}
+	}
+	return i.writeGyroOffsetToUserRegister(offsets)
+}
 
+func (i *IIM42652) writeGyroOffsetToUserRegister(offsets [3]int16) error {
 	// The accelerometer bias data shares a register with the gyrosocope.
-	// Copy the overlapping data to data[4] so that we don't lose it.
+	// Read it so that we don't lose it.
 	accelData, err := i.ReadRegister(RegisterOffsetUser4)
 	if err != nil {
 		return err
 	}
-	data[4] = (accelData & bitAccelXOffuserMaskHi)
-
-	cur_bias := convertGyroBiasToRegisterFormat(bias[0])
-	data[0] = storeLowBits(cur_bias, bitGyroXOffuserPosLo)
-	data[1] = storeHighBits(cur_bias, bitGyroXOffuserPosHi)
 
-	cur_bias = convertGyroBiasToRegisterFormat(bias[1])
-	data[1] |= storeHighBits(cur_bias, bitGyroYOffuserPosHi)
-	data[2] = storeLowBits(cur_bias, bitGyroYOffuserPosLo)
-
-	cur_bias = convertGyroBiasToRegisterFormat(bias[2])
-	data[3] = storeLowBits(cur_bias, bitGyroZOffuserPosLo)
-	data[4] |= storeHighBits(cur_bias, bitGyroZOffuserPosHi)
+	data := packGyroOffsets(offsets, accelData)
 
 	userRegister := *RegisterOffsetUser0
 	for idx := 0; idx < len(data); idx++ {
@@ -210,6 +309,57 @@ func (i *IIM42652) writeGyroBiasToUserRegister(bias [3]int32) error {
 	return nil
 }
 
+// Reads back the gyro offsets currently programmed in the user registers,
+// in register units (1/32 dps).
+func (i *IIM42652) readGyroOffsetFromUserRegister() (offsets [3]int16, err error) {
+	data := [5]byte{0, 0, 0, 0, 0}
+
+	userRegister := *RegisterOffsetUser0
+	for idx := 0; idx < len(data); idx++ {
+		data[idx], err = i.ReadRegister(&userRegister)
+		if err != nil {
+			return offsets, err
+		}
+		userRegister.Address += 1
+	}
+
+	return unpackGyroOffsets(data), nil
+}
+
+// The 3 offsets are stored as 12 bits each.
+// They need to be stored interleaved across 5 byte registers
+// Look at the IIM42652 datasheet for more info.
+// The last register is shared with the accelerometer X offset, its
+// current value must be passed as accelData so that we don't lose it.
+func packGyroOffsets(offsets [3]int16, accelData byte) [5]byte {
+	data := [5]byte{0, 0, 0, 0, 0}
+	data[4] = (accelData & bitAccelXOffuserMaskHi)
+
+	data[0] = storeLowBits(offsets[0], bitGyroXOffuserPosLo)
+	data[1] = storeHighBits(offsets[0], bitGyroXOffuserPosHi)
+
+	data[1] |= storeHighBits(offsets[1], bitGyroYOffuserPosHi)
+	data[2] = storeLowBits(offsets[1], bitGyroYOffuserPosLo)
+
+	data[3] = storeLowBits(offsets[2], bitGyroZOffuserPosLo)
+	data[4] |= storeHighBits(offsets[2], bitGyroZOffuserPosHi)
+
+	return data
+}
+
+func unpackGyroOffsets(data [5]byte) [3]int16 {
+	return [3]int16{
+		signExtend12(uint16(data[1]&bitGyroXOffuserMaskHi)<<8 | uint16(data[0])),
+		signExtend12(uint16((data[1]&bitGyroYOffuserMaskHi)>>bitGyroYOffuserPosHi)<<8 | uint16(data[2])),
+		signExtend12(uint16(data[4]&bitGyroZOffuserMaskHi)<<8 | uint16(data[3])),
+	}
+}
+
+// Sign extend a 12 bits two's complement value.
+func signExtend12(value uint16) int16 {
+	return int16(value<<4) >> 4
+}
+
 // Calibrates the gyro by taking an average and
 // storing the offset
 func (i *IIM42652) CalibrateGyro(maxSamples int32) (bias [3]int32, err error) {
@@ -241,69 +391,75 @@ func (i *IIM42652) ClearGyroBias() error {
 ////////////////////////////////////////////////////////////
 
 func (i *IIM42652) AverageAccelerometerSensorOutput(maxSamples int32) (average [3]int32, err error) {
-	numSamples := int32(0)
-	samplesDiscarded := int32(0)
-	sum := [3]int32{0, 0, 0}
-	for {
+	stats, err := i.collectAccelerometerStatistics(maxSamples)
+	if err != nil {
+		return average, err
+	}
+	return stats.average()
+}
+
+func (i *IIM42652) collectAccelerometerStatistics(maxSamples int32) (*rawStatistics, error) {
+	stats := &rawStatistics{}
+	for stats.samples < maxSamples {
 		accelerometerData, err := i.GetAcceleration()
 		if err != nil {
-			return average, err
-		}
-
-		if accelerometerData.RawX != -32768 && accelerometerData.RawY != -32768 && accelerometerData.RawZ != -32768 {
-			sum[0] += int32(accelerometerData.RawX)
-			sum[1] += int32(accelerometerData.RawY)
-			sum[2] += int32(accelerometerData.RawZ)
-		} else {
-			samplesDiscarded++
+			return nil, err
 		}
-
-		numSamples++
+		stats.add(accelerometerData.RawX, accelerometerData.RawY, accelerometerData.RawZ)
 		time.Sleep(time.Millisecond)
-		if numSamples == maxSamples {
-			break
-		}
 	}
+	return stats, nil
+}
 
-	// compute average value
-	numSamples -= samplesDiscarded
-	average[0] = (sum[0] / numSamples)
-	average[1] = (sum[1] / numSamples)
-	average[2] = (sum[2] / numSamples)
-	return average, nil
+// Reads the accelerometer full scale range, in g, currently configured on the device.
+func (i *IIM42652) readAccelerometerFullScaleRange() (float64, error) {
+	accelConfig, err := i.ReadRegister(RegisterAccelConfig)
+	if err != nil {
+		return 0, err
+	}
+	return accelerometerFullScaleRange(accelConfig)
 }
 
-// Negate the bias value and convert the reading to the
-// 1g sensitivity equivalent.
-func convertAccelBiasToRegisterFormat(accelBias int32) int16 {
-	return int16(-accelBias)
+func accelerometerFullScaleRange(accelConfig byte) (float64, error) {
+	fsSelect := (accelConfig & bitAccelConfig0FSSelectMask) >> bitAccelFsSelectPos
+	if int(fsSelect) >= len(accelFullScaleRangesG) {
+		return 0, fmt.Errorf("reserved accelerometer FS_SEL value %d", fsSelect)
+	}
+	return accelFullScaleRangesG[fsSelect], nil
+}
+
+// Negate the bias value, read at fullScaleG, and convert it to
+// the 0.5 mg unit of the user registers.
+func convertAccelBiasToRegisterFormat(accelBias int32, fullScaleG float64) (int16, error) {
+	offset, err := clampUserOffset(-float64(accelBias) * fullScaleG / rawFullScale * accelOffuserUnitsPerG)
+	if err != nil {
+		return offset, fmt.Errorf("accelerometer bias of %d at %vg: %w", accelBias, fullScaleG, err)
+	}
+	return offset, nil
 }
 
 func (i *IIM42652) writeAccelerometerBiasToUserRegister(bias [3]int32) error {
-	// The 3 bias values are stored as 12 bits each.
-	// They need to be stored interleaved across 5 byte registers
-	// Look at the IIM42652 datasheet for more info.
-	data := [5]byte{0, 0, 0, 0, 0}
+	fullScale, err := i.readAccelerometerFullScaleRange()
+	if err != nil {
+		return fmt.Errorf("reading accelerometer full scale range: %w", err)
+	}
+
+	var offsets [3]int16
+	for axis := 0; axis < 3; axis++ {
+		offsets[axis], err = convertAccelBiasToRegisterFormat(bias[axis], fullScale)
+		if err != nil {
+			return err
+		}
+	}
 
 	// The gyroscope bias data shares a register with the accelerometer.
-	// Copy the overlapping data to data[0] so that we don't lose it.
+	// Read it so that we don't lose it.
 	gyroData, err := i.ReadRegister(RegisterOffsetUser4)
 	if err != nil {
 		return err
 	}
-	data[0] = (gyroData & bitGyroZOffuserMaskHi)
-
-	cur_bias := convertAccelBiasToRegisterFormat(bias[0])
-	data[0] |= storeHighBits(cur_bias, bitAccelXOffuserPosHi)
-	data[1] = storeLowBits(cur_bias, bitAccelXOffuserPosLo)
-
-	cur_bias = convertAccelBiasToRegisterFormat(bias[1])
-	data[2] = storeLowBits(cur_bias, bitAccelYOffuserPosLo)
-	data[3] = storeHighBits(cur_bias, bitAccelYOffuserPosHi)
 
-	cur_bias = convertAccelBiasToRegisterFormat(bias[2])
-	data[3] |= storeHighBits(cur_bias, bitAccelZOffuserPosHi)
-	data[4] = storeLowBits(cur_bias, bitAccelZOffuserPosLo)
+	data := packAccelerometerOffsets(offsets, gyroData)
 
 	userRegister := *RegisterOffsetUser4
 	for idx := 0; idx < len(data); idx++ {
@@ -317,12 +473,89 @@ func (i *IIM42652) writeAccelerometerBiasToUserRegister(bias [3]int32) error {
 	return nil
 }
 
+// The 3 offsets are stored as 12 bits each, interleaved across the 5 byte
+// registers starting at OFFSET_USER4. The first register is shared with the
+// gyro Z offset, its current value must be passed as gyroData so that we
+// don't lose it.
+func packAccelerometerOffsets(offsets [3]int16, gyroData byte) [5]byte {
+	data := [5]byte{0, 0, 0, 0, 0}
+	data[0] = (gyroData & bitGyroZOffuserMaskHi)
+
+	data[0] |= storeHighBits(offsets[0], bitAccelXOffuserPosHi)
+	data[1] = storeLowBits(offsets[0], bitAccelXOffuserPosLo)
+
+	data[2] = storeLowBits(offsets[1], bitAccelYOffuserPosLo)
+	data[3] = storeHighBits(offsets[1], bitAccelYOffuserPosHi)
+
+	data[3] |= storeHighBits(offsets[2], bitAccelZOffuserPosHi)
+	data[4] = storeLowBits(offsets[2], bitAccelZOffuserPosLo)
+
+	return data
+}
+
+// Reads back the accelerometer offsets currently programmed in the user
+// registers, in register units (0.5 mg).
+func (i *IIM42652) readAccelerometerOffsetFromUserRegister() (offsets [3]int16, err error) {
+	data := [5]byte{0, 0, 0, 0, 0}
+
+	userRegister := *RegisterOffsetUser4
+	for idx := 0; idx < len(data); idx++ {
+		data[idx], err = i.ReadRegister(&userRegister)
+		if err != nil {
+			return offsets, err
+		}
+		userRegister.Address += 1
+	}
+
+	return unpackAccelerometerOffsets(data), nil
+}
+
+func unpackAccelerometerOffsets(data [5]byte) [3]int16 {
+	return [3]int16{
+		signExtend12(uint16((data[0]&bitAccelXOffuserMaskHi)>>bitAccelXOffuserPosHi)<<8 | uint16(data[1])),
+		signExtend12(uint16(data[3]&bitAccelYOffuserMaskHi)<<8 | uint16(data[2])),
+		signExtend12(uint16((data[3]&bitAccelZOffuserMaskHi)>>bitAccelZOffuserPosHi)<<8 | uint16(data[4])),
+	}
+}
+
+// Removes the 1g of gravity from the axis measuring the most of it, so that
+// only the bias is written to the user registers.
+func removeGravity(average [3]int32, fullScaleG float64) [3]int32 {
+	gravityAxis := 0
+	for axis := 1; axis < 3; axis++ {
+		if abs(average[axis]) > abs(average[gravityAxis]) {
+			gravityAxis = axis
+		}
+	}
+	gravity := int32(math.Round(rawFullScale / fullScaleG))
+	if average[gravityAxis] < 0 {
+		gravity = -gravity
+	}
+	average[gravityAxis] -= gravity
+	return average
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// CalibrateAccelerometer returns the bias written to the user registers, the
+// device must be level so that gravity lies along one of its axes.
 func (i *IIM42652) CalibrateAccelerometer(maxSamples int32) (bias [3]int32, err error) {
-	bias, err = i.AverageAccelerometerSensorOutput(maxSamples)
+	average, err := i.AverageAccelerometerSensorOutput(maxSamples)
 	if err != nil {
 		return bias, err
 	}
 
+	fullScale, err := i.readAccelerometerFullScaleRange()
+	if err != nil {
+		return bias, fmt.Errorf("reading accelerometer full scale range: %w", err)
+	}
+	bias = removeGravity(average, fullScale)
+
 	err = i.writeAccelerometerBiasToUserRegister(bias)
 	if err != nil {
 		return bias, err
@@ -0,0 +1,127 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertGyroBiasToRegisterFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		biasDps        float64
+		expectedOffset int16
+		expectedError  bool
+	}{
+		{"zero", 0, 0, false},
+		{"positive", 10, -320, false},
+		{"negative", -2.5, 80, false},
+		{"near upper limit", -63.9, 2045, false},
+		{"near lower limit", 63.9, -2045, false},
+		{"overflow", -70, 2047, true},
+		{"underflow", 70, -2048, true},
+	}
+
+	for fsSelect, fullScale := range gyroFullScaleRangesDps {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%v dps %s", fullScale, test.name), func(t *testing.T) {
+				// Biases that cannot be read at this range are skipped.
+				raw := test.biasDps / fullScale * rawFullScale
+				if raw >= rawFullScale || raw < -rawFullScale {
+					t.Skip("bias outside of the full scale range")
+				}
+
+				assert.Equal(t, fullScale, gyroFullScaleRange(byte(fsSelect)<<bitGyroFsSelectPos))
+
+				offset, err := convertGyroBiasToRegisterFormat(int32(math.Round(raw)), fullScale)
+				if test.expectedError {
+					require.ErrorIs(t, err, ErrUserOffsetOverflow)
+				} else {
+					require.NoError(t, err)
+				}
+				// One raw LSB can be worth more than one register unit.
+				assert.InDelta(t, test.expectedOffset, offset, 1+fullScale/rawFullScale*gyroOffuserUnitsPerDps)
+			})
+		}
+	}
+}
+
+func Test_ConvertAccelBiasToRegisterFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		biasG          float64
+		expectedOffset int16
+		expectedError  bool
+	}{
+		{"zero", 0, 0, false},
+		{"positive", 0.1, -200, false},
+		{"negative", -0.25, 500, false},
+		{"gravity", 1, -2000, false},
+		{"upper limit", -1.0235, 2047, false},
+		{"overflow", -1.5, 2047, true},
+		{"underflow", 1.5, -2048, true},
+	}
+
+	for fsSelect, fullScale := range accelFullScaleRangesG {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%vg %s", fullScale, test.name), func(t *testing.T) {
+				raw := test.biasG / fullScale * rawFullScale
+				if raw >= rawFullScale || raw < -rawFullScale {
+					t.Skip("bias outside of the full scale range")
+				}
+
+				fullScaleRange, err := accelerometerFullScaleRange(byte(fsSelect) << bitAccelFsSelectPos)
+				require.NoError(t, err)
+				assert.Equal(t, fullScale, fullScaleRange)
+
+				offset, err := convertAccelBiasToRegisterFormat(int32(math.Round(raw)), fullScale)
+				if test.expectedError {
+					require.ErrorIs(t, err, ErrUserOffsetOverflow)
+				} else {
+					require.NoError(t, err)
+				}
+				assert.InDelta(t, test.expectedOffset, offset, 1+fullScale/rawFullScale*accelOffuserUnitsPerG)
+			})
+		}
+	}
+}
+
+func Test_RemoveGravity(t *testing.T) {
+	tests := []struct {
+		name            string
+		averageG        [3]float64
+		expectedOffsets [3]int16
+	}{
+		{"level", [3]float64{0, 0, 1}, [3]int16{0, 0, 0}},
+		{"bias on every axis", [3]float64{0.01, -0.02, 1.005}, [3]int16{-20, 40, -10}},
+		{"above the user offset range", [3]float64{0, 0, 1.03}, [3]int16{0, 0, -60}},
+		{"upside down", [3]float64{0, 0, -1.03}, [3]int16{0, 0, 60}},
+		{"mounted sideways", [3]float64{0, -0.98, 0.01}, [3]int16{0, -40, -20}},
+	}
+
+	for _, fullScale := range accelFullScaleRangesG {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%vg %s", fullScale, test.name), func(t *testing.T) {
+				var average [3]int32
+				for axis := range average {
+					average[axis] = int32(math.Round(test.averageG[axis] / fullScale * rawFullScale))
+				}
+
+				bias := removeGravity(average, fullScale)
+				for axis := range bias {
+					offset, err := convertAccelBiasToRegisterFormat(bias[axis], fullScale)
+					require.NoError(t, err)
+					assert.InDelta(t, test.expectedOffsets[axis], offset, 1+fullScale/rawFullScale*accelOffuserUnitsPerG)
+				}
+			})
+		}
+	}
+}
+
+func Test_AccelerometerFullScaleRangeReserved(t *testing.T) {
+	_, err := accelerometerFullScaleRange(0x04 << bitAccelFsSelectPos)
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package iim42652
+
+import "fmt"
+
+// Configuration is a snapshot of the device settings raw samples depend on.
+type Configuration struct {
+	AccelerometerFullScale float64 `json:"accelerometer_full_scale_g"`
+	AccelerometerODR       float64 `json:"accelerometer_odr_hz"`
+	GyroFullScale          float64 `json:"gyro_full_scale_dps"`
+	GyroODR                float64 `json:"gyro_odr_hz"`
+	// Offsets programmed in the user registers, in register units: 1/32 dps
+	// for the gyro and 0.5 mg for the accelerometer. The device adds them to
+	// the raw values, the calibration programs the negated bias.
+	GyroOffsets          [3]int16 `json:"gyro_offsets"`
+	AccelerometerOffsets [3]int16 `json:"accelerometer_offsets"`
+	// Sensitivities the driver converts raw values with, see NewSpi.
+	AccelerationSensitivity AccelerationSensitivity `json:"acceleration_sensitivity_g_per_lsb"`
+	GyroScale               GyroScale               `json:"gyro_scale_dps_per_lsb"`
+}
+
+// ReadConfiguration reads the full scale ranges, output data rates and user
+// offsets currently configured on the device. While SelfTest runs, it returns
+// the configuration the test restores instead of the test settings.
+func (i *IIM42652) ReadConfiguration() (*Configuration, error) {
+	i.selfTestLock.Lock()
+	saved := i.selfTestConfiguration
+	i.selfTestLock.Unlock()
+	if saved != nil {
+		configuration := *saved
+		return &configuration, nil
+	}
+
+	config := &Configuration{
+		AccelerationSensitivity: i.accelerationSensitivity,
+		GyroScale:               i.gyroScale,
+	}
+
+	var err error
+	if config.AccelerometerFullScale, err = i.readAccelerometerFullScaleRange(); err != nil {
+		return nil, fmt.Errorf("reading accelerometer full scale range: %w", err)
+	}
+	if config.AccelerometerODR, err = i.AccelerometerOutputDataRate(); err != nil {
+		return nil, fmt.Errorf("reading accelerometer output data rate: %w", err)
+	}
+	if config.GyroFullScale, err = i.readGyroFullScaleRange(); err != nil {
+		return nil, fmt.Errorf("reading gyro full scale range: %w", err)
+	}
+	if config.GyroODR, err = i.GyroOutputDataRate(); err != nil {
+		return nil, fmt.Errorf("reading gyro output data rate: %w", err)
+	}
+	if config.GyroOffsets, err = i.readGyroOffsetFromUserRegister(); err != nil {
+		return nil, fmt.Errorf("reading gyro offsets: %w", err)
+	}
+	if config.AccelerometerOffsets, err = i.readAccelerometerOffsetFromUserRegister(); err != nil {
+		return nil, fmt.Errorf("reading accelerometer offsets: %w", err)
+	}
+	return config, nil
+}
+
+// GyroOffsetsDps returns the gyro user offsets in dps. They are the
+// corrections added by the device, the opposite of the calibrated bias.
+func (c *Configuration) GyroOffsetsDps() (offsets [3]float64) {
+	for axis, offset := range c.GyroOffsets {
+		offsets[axis] = float64(offset) / gyroOffuserUnitsPerDps
+	}
+	return offsets
+}
+
+// AccelerometerOffsetsG returns the accelerometer user offsets in g. They are
+// the corrections added by the device, the opposite of the calibrated bias.
+func (c *Configuration) AccelerometerOffsetsG() (offsets [3]float64) {
+	for axis, offset := range c.AccelerometerOffsets {
+		offsets[axis] = float64(offset) / accelOffuserUnitsPerG
+	}
+	return offsets
+}
+
+func (i *IIM42652) setSelfTestConfiguration(configuration *Configuration) {
+	i.selfTestLock.Lock()
+	defer i.selfTestLock.Unlock()
+	i.selfTestConfiguration = configuration
+}
@@ -0,0 +1,19 @@
+package iim42652
+
+import (
+	"context"
+	"time"
+)
+
+// Device is the sample reading API of the driver. It is implemented by
+// IIM42652 and by replay.Device, so that code written against it runs on
+// recorded data too.
+type Device interface {
+	GetAcceleration() (*Acceleration, error)
+	GetGyroscopeData() (*AngularRate, error)
+	GetTemperature() (Temperature, error)
+	GetSample() (*Sample, error)
+	Stream(ctx context.Context, period time.Duration, samples chan<- *Sample) error
+}
+
+var _ Device = (*IIM42652)(nil)
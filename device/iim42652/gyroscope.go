@@ -37,8 +37,15 @@ func NewGyroscope(x, y, z int16, gyroScale GyroScale) *AngularRate {
 	}
 }
 
+// Valid reports whether the gyroscope was on, it reads InvalidData otherwise.
+func (a *AngularRate) Valid() bool {
+	return a.RawX != InvalidData && a.RawY != InvalidData && a.RawZ != InvalidData
+}
+
+// String prints the values along the IMU axes, use a MountTransform for the
+// camera frame.
 func (a *AngularRate) String() string {
-	return fmt.Sprintf("AngularRate: camX:%.5f, camY:%.5f, camZ: %.5f", a.CamX(), a.CamY(), a.CamZ())
+	return fmt.Sprintf("AngularRate{imuX:%.5f, imuY:%.5f, imuZ:%.5f}", a.X, a.Y, a.Z)
 }
 
 func (a *AngularRate) CamX() float64 {
@@ -78,7 +85,7 @@ func (i *IIM42652) GetGyroscopeData() (*AngularRate, error) {
 	msg := make([]byte, 7)
 	result := make([]byte, 7)
 	msg[0] = ReadMask | byte(RegisterGyroscopeDataX1.Address)
-	if err := i.connection.Tx(msg, result); err != nil {
+	if err := i.tx(RegisterGyroscopeDataX1, false, msg, result); err != nil {
 		return nil, fmt.Errorf("reading to SPI port: %w", err)
 	}
 
@@ -0,0 +1,48 @@
+package iim42652
+
+import (
+	"time"
+)
+
+// Metrics receives the health measurements of the driver, see the metrics
+// package for a Prometheus implementation. The methods are called from the
+// reading goroutine and must not block.
+type Metrics interface {
+	// SPITransaction is called after every transaction on the bus, reg is the
+	// first register read or written.
+	SPITransaction(reg *Register, write bool, err error)
+	// SampleRead is called after every GetSample with the time it took.
+	SampleRead(latency time.Duration, err error)
+	// SampleStreamed is called for every sample read by Stream, dropped when
+	// the consumer was too slow to receive it.
+	SampleStreamed(sample *Sample, dropped bool)
+	// PeriodsMissed is called when Stream skipped sample periods because
+	// reading the previous sample took longer than the period. These are not
+	// FIFO overflows: the driver polls the data registers and leaves the FIFO
+	// disabled, so the samples produced meanwhile are overwritten unseen and
+	// the device has nothing to report.
+	PeriodsMissed(missed int)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) SPITransaction(*Register, bool, error) {}
+func (noopMetrics) SampleRead(time.Duration, error)       {}
+func (noopMetrics) SampleStreamed(*Sample, bool)          {}
+func (noopMetrics) PeriodsMissed(int)                     {}
+
+// SetMetrics reports the measurements of the device to metrics, nil stops
+// reporting them. It must be called before reading the device.
+func (i *IIM42652) SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+	i.metrics = metrics
+}
+
+// tx runs a transaction on the bus and reports it.
+func (i *IIM42652) tx(reg *Register, write bool, w, r []byte) error {
+	err := i.connection.Tx(w, r)
+	i.metrics.SPITransaction(reg, write, err)
+	return err
+}
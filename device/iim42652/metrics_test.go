@@ -0,0 +1,90 @@
+package iim42652
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"periph.io/x/conn/v3/spi"
+)
+
+// failingConn fails the transactions reading failAddress.
+type failingConn struct {
+	spi.Conn
+	failAddress Address
+}
+
+func (c *failingConn) Tx(w, r []byte) error {
+	if w[0] == ReadMask|byte(c.failAddress) {
+		return errors.New("bus error")
+	}
+	return nil
+}
+
+type transaction struct {
+	register *Register
+	write    bool
+	failed   bool
+}
+
+type recordingMetrics struct {
+	transactions []transaction
+	reads        []error
+}
+
+func (m *recordingMetrics) SPITransaction(reg *Register, write bool, err error) {
+	m.transactions = append(m.transactions, transaction{reg, write, err != nil})
+}
+
+func (m *recordingMetrics) SampleRead(latency time.Duration, err error) {
+	m.reads = append(m.reads, err)
+}
+
+func (m *recordingMetrics) SampleStreamed(*Sample, bool) {}
+func (m *recordingMetrics) PeriodsMissed(int)            {}
+
+func Test_Metrics(t *testing.T) {
+	device := NewSpi("", AccelerationSensitivityG16, GyroScalesG2000, false, true)
+	device.connection = &failingConn{failAddress: RegisterTemperatureData.Address}
+	metrics := &recordingMetrics{}
+	device.SetMetrics(metrics)
+
+	_, err := device.GetSample()
+	require.Error(t, err)
+	assert.Equal(t, []transaction{
+		{RegisterAccelDataX1, false, false},
+		{RegisterGyroscopeDataX1, false, false},
+		{RegisterTemperatureData, false, true},
+	}, metrics.transactions)
+	require.Len(t, metrics.reads, 1)
+	assert.Error(t, metrics.reads[0])
+
+	// The bank is selected before the register is written.
+	metrics.transactions = nil
+	require.NoError(t, device.WriteRegister(RegisterAccelWomXThreshold, 0x10))
+	assert.Equal(t, []transaction{
+		{RegisterBankSel, true, false},
+		{RegisterAccelWomXThreshold, true, false},
+	}, metrics.transactions)
+}
+
+func Test_MissedPeriods(t *testing.T) {
+	start := time.Unix(0, 0)
+	period := 10 * time.Millisecond
+	tests := []struct {
+		elapsed time.Duration
+		missed  int
+	}{
+		{10 * time.Millisecond, 0},
+		{12 * time.Millisecond, 0},
+		{20 * time.Millisecond, 1},
+		{47 * time.Millisecond, 4},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.missed, missedPeriods(start, start.Add(test.elapsed), period), test.elapsed)
+	}
+	assert.Equal(t, 0, missedPeriods(time.Time{}, start, period))
+}
@@ -0,0 +1,130 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tolerance used when checking that a rotation is orthonormal.
+const mountRotationTolerance = 1e-3
+
+// MountTransform converts readings from the IMU frame into the camera frame
+// (X forward, Y left, Z up). It applies to accelerations, angular rates and
+// full samples alike, and is validated when it is built.
+type MountTransform struct {
+	rotation RotationMatrix
+	// -1 when the rotation mirrors the frame, +1 otherwise.
+	determinant float64
+}
+
+// NewMountTransform builds a transform from a rotation matrix, each row being
+// a camera axis expressed in IMU coordinates. The matrix must be orthonormal.
+// A determinant of -1 mirrors the frame, like an AxisMap inverting a single
+// axis: angular rates being axial vectors, they are negated on top of the
+// rotation so that they stay consistent with the mirrored accelerations.
+func NewMountTransform(rotation RotationMatrix) (*MountTransform, error) {
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			if math.IsNaN(rotation[row][col]) || math.IsInf(rotation[row][col], 0) {
+				return nil, fmt.Errorf("rotation contains an invalid value at [%d][%d]", row, col)
+			}
+
+			expected := 0.0
+			if row == col {
+				expected = 1.0
+			}
+			if math.Abs(dot(rotation[row], rotation[col])-expected) > mountRotationTolerance {
+				return nil, fmt.Errorf("rotation is not orthonormal, rows %d and %d", row, col)
+			}
+		}
+	}
+
+	determinant := 1.0
+	if dot(cross(rotation[0], rotation[1]), rotation[2]) < 0 {
+		determinant = -1
+	}
+
+	return &MountTransform{rotation: rotation, determinant: determinant}, nil
+}
+
+// NewMountTransformFromQuaternion builds a transform from the unit quaternion
+// w + xi + yj + zk rotating the IMU frame into the camera frame.
+func NewMountTransformFromQuaternion(w, x, y, z float64) (*MountTransform, error) {
+	norm := math.Sqrt(w*w + x*x + y*y + z*z)
+	if math.Abs(norm-1) > mountRotationTolerance {
+		return nil, fmt.Errorf("quaternion is not a unit quaternion, norm is %v", norm)
+	}
+	w, x, y, z = w/norm, x/norm, y/norm, z/norm
+
+	return NewMountTransform(RotationMatrix{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	})
+}
+
+// NewMountTransformFromAxisMap builds the transform equivalent to an axis
+// permutation.
+func NewMountTransformFromAxisMap(axisMap *AxisMap) (*MountTransform, error) {
+	if err := axisMap.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid axis map: %w", err)
+	}
+
+	var rotation RotationMatrix
+	camAxes := []ImuAxis{axisMap.CamX, axisMap.CamY, axisMap.CamZ}
+	inverted := []bool{axisMap.InvX, axisMap.InvY, axisMap.InvZ}
+	for camAxis, imuAxis := range camAxes {
+		value := 1.0
+		if inverted[camAxis] {
+			value = -1.0
+		}
+		rotation[camAxis][imuAxis.index()] = value
+	}
+
+	return NewMountTransform(rotation)
+}
+
+func (m *MountTransform) Rotation() RotationMatrix {
+	return m.rotation
+}
+
+// Mirrored reports whether the transform is a reflection rather than a proper
+// rotation.
+func (m *MountTransform) Mirrored() bool {
+	return m.determinant < 0
+}
+
+// Apply rotates a vector from the IMU frame to the camera frame.
+func (m *MountTransform) Apply(v [3]float64) [3]float64 {
+	return m.rotation.Apply(v)
+}
+
+// Acceleration returns the acceleration in the camera frame, in g.
+func (m *MountTransform) Acceleration(acceleration *Acceleration) [3]float64 {
+	return m.rotation.Apply([3]float64{acceleration.X, acceleration.Y, acceleration.Z})
+}
+
+// AngularRate returns the angular rate in the camera frame, in dps.
+func (m *MountTransform) AngularRate(angularRate *AngularRate) [3]float64 {
+	rate := m.rotation.Apply([3]float64{angularRate.X, angularRate.Y, angularRate.Z})
+	for axis := range rate {
+		rate[axis] *= m.determinant
+	}
+	return rate
+}
+
+// Sample converts a full sample, an angular rate read while the gyroscope was
+// off is zeroed and flagged with InvalidAngularRate.
+func (m *MountTransform) Sample(sample *Sample) *CameraSample {
+	camera := &CameraSample{
+		Time:         sample.Time,
+		Acceleration: m.Acceleration(sample.Acceleration),
+		Temperature:  sample.Temperature,
+	}
+	if sample.AngularRate.Valid() {
+		camera.AngularRate = m.AngularRate(sample.AngularRate)
+	} else {
+		camera.InvalidAngularRate = true
+	}
+	return camera
+}
@@ -0,0 +1,164 @@
+package iim42652
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MountTransform(t *testing.T) {
+	half := math.Sqrt(0.5)
+
+	tests := []struct {
+		name          string
+		transform     func() (*MountTransform, error)
+		input         [3]float64
+		expected      [3]float64
+		expectedError bool
+	}{
+		{
+			name:      "default axis map",
+			transform: DefaultAxisMap().Transform,
+			input:     [3]float64{1, 2, 3},
+			expected:  [3]float64{3, 1, 2},
+		},
+		{
+			name: "axis map with inverted axes",
+			transform: (&AxisMap{
+				CamX: "Z", CamY: "X", CamZ: "Y",
+				InvX: true, InvY: true,
+			}).Transform,
+			input:    [3]float64{1, 2, 3},
+			expected: [3]float64{-3, -1, 2},
+		},
+		{
+			name: "rotation matrix",
+			transform: func() (*MountTransform, error) {
+				return NewMountTransform(RotationMatrix{
+					{half, 0, -half},
+					{0, 1, 0},
+					{half, 0, half},
+				})
+			},
+			input:    [3]float64{0, 0, 1},
+			expected: [3]float64{-half, 0, half},
+		},
+		{
+			name: "quaternion 90 degrees around Z",
+			transform: func() (*MountTransform, error) {
+				return NewMountTransformFromQuaternion(half, 0, 0, half)
+			},
+			input:    [3]float64{1, 0, 0},
+			expected: [3]float64{0, 1, 0},
+		},
+		{
+			name: "invalid axis",
+			transform: (&AxisMap{
+				CamX: "W", CamY: "X", CamZ: "Y",
+			}).Transform,
+			expectedError: true,
+		},
+		{
+			name: "axis mapped twice",
+			transform: (&AxisMap{
+				CamX: "X", CamY: "X", CamZ: "Y",
+			}).Transform,
+			expectedError: true,
+		},
+		{
+			name: "not orthonormal",
+			transform: func() (*MountTransform, error) {
+				return NewMountTransform(RotationMatrix{
+					{1, 0, 0},
+					{0.5, 1, 0},
+					{0, 0, 1},
+				})
+			},
+			expectedError: true,
+		},
+		{
+			name: "not a unit quaternion",
+			transform: func() (*MountTransform, error) {
+				return NewMountTransformFromQuaternion(1, 1, 0, 0)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transform, err := test.transform()
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			acceleration := transform.Acceleration(&Acceleration{X: test.input[0], Y: test.input[1], Z: test.input[2]})
+			angularRate := transform.AngularRate(&AngularRate{X: test.input[0], Y: test.input[1], Z: test.input[2]})
+			for axis := 0; axis < 3; axis++ {
+				assert.InDelta(t, test.expected[axis], acceleration[axis], 1e-9)
+				assert.InDelta(t, test.expected[axis], angularRate[axis], 1e-9)
+			}
+		})
+	}
+}
+
+func Test_MountTransformSample(t *testing.T) {
+	transform, err := DefaultAxisMap().Transform()
+	require.NoError(t, err)
+
+	sample := &Sample{
+		Time:         time.Unix(10, 0),
+		Acceleration: NewAcceleration(100, 200, 300, AccelerationSensitivityG16),
+		AngularRate:  NewGyroscope(-10, 20, -30, GyroScalesG2000),
+		Temperature:  30,
+	}
+	cameraSample := transform.Sample(sample)
+
+	assert.Equal(t, sample.Time, cameraSample.Time)
+	assert.Equal(t, sample.Temperature, cameraSample.Temperature)
+	assert.Equal(t, [3]float64{sample.Acceleration.CamX(), sample.Acceleration.CamY(), sample.Acceleration.CamZ()}, cameraSample.Acceleration)
+	assert.Equal(t, [3]float64{sample.AngularRate.CamX(), sample.AngularRate.CamY(), sample.AngularRate.CamZ()}, cameraSample.AngularRate)
+	assert.False(t, cameraSample.InvalidAngularRate)
+
+	sample.AngularRate = NewGyroscope(InvalidData, InvalidData, InvalidData, GyroScalesG2000)
+	cameraSample = transform.Sample(sample)
+	assert.True(t, cameraSample.InvalidAngularRate, "the gyroscope is off")
+	assert.Equal(t, [3]float64{}, cameraSample.AngularRate)
+}
+
+func Test_MirroredMountTransform(t *testing.T) {
+	axisMap := NewAxisMap("X", "Y", "Z")
+	axisMap.SetInvertedAxes(true, false, false)
+	transform, err := axisMap.Transform()
+	require.NoError(t, err)
+	assert.True(t, transform.Mirrored())
+
+	acceleration := &Acceleration{X: 1, Y: 2, Z: 3}
+	expected, err := axisMap.Camera(acceleration)
+	require.NoError(t, err)
+	assert.Equal(t, expected, transform.Acceleration(acceleration))
+	assert.Equal(t, [3]float64{1, -2, -3}, transform.AngularRate(&AngularRate{X: 1, Y: 2, Z: 3}))
+
+	transform, err = DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	assert.False(t, transform.Mirrored())
+}
+
+func Test_ParseAxisMap(t *testing.T) {
+	_, err := ParseAxisMap("Z", "X", "Y")
+	require.NoError(t, err)
+
+	_, err = ParseAxisMap("Z", "X", "Q")
+	require.Error(t, err)
+
+	// Invalid maps built directly are reported instead of panicking.
+	axisMap := NewAxisMap("Z", "X", "Q")
+	assert.True(t, math.IsNaN(axisMap.Z(&Acceleration{Z: 1})))
+	_, err = axisMap.Camera(&Acceleration{Z: 1})
+	assert.Error(t, err)
+}
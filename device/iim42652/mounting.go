@@ -0,0 +1,104 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+)
+
+// Thresholds used by DetectMounting.
+const (
+	// The stationary samples must measure gravity within this tolerance (g).
+	mountingGravityTolerance = 0.2
+	// Minimum horizontal acceleration (g) of the straight-line segment.
+	mountingMinimumAcceleration = 0.05
+	// Maximum angle (degrees) between a camera axis and an IMU axis for the
+	// mount to be described by an AxisMap.
+	mountingAxisAlignmentTolerance = 10.0
+)
+
+// Mounting describes how the IMU is mounted in the vehicle. The camera frame
+// is X forward, Y left and Z up.
+type Mounting struct {
+	// Rotation from the IMU frame to the camera frame.
+	Rotation RotationMatrix
+	// AxisMap equivalent to Rotation, nil when the IMU axes are not aligned
+	// with the vehicle axes (tilted mount).
+	AxisMap *AxisMap
+}
+
+// DetectMounting infers the mounting orientation of the IMU from samples
+// taken while the vehicle is stationary and samples taken while it is
+// accelerating forward in a straight line (e.g. pulling away from a stop).
+//
+// The stationary samples give the up axis (the accelerometer measures +1g
+// against gravity), the accelerating samples, once gravity is removed, give
+// the forward axis. Left completes the right-handed frame.
+func DetectMounting(stationary []*Acceleration, accelerating []*Acceleration) (*Mounting, error) {
+	if len(stationary) == 0 {
+		return nil, fmt.Errorf("no stationary samples")
+	}
+	if len(accelerating) == 0 {
+		return nil, fmt.Errorf("no accelerating samples")
+	}
+
+	gravity := averageAcceleration(stationary)
+	gravityMagnitude := magnitude(gravity)
+	if math.Abs(gravityMagnitude-1) > mountingGravityTolerance {
+		return nil, fmt.Errorf("stationary samples measure %.3fg, expected 1g, was the vehicle moving?", gravityMagnitude)
+	}
+	up := normalize(gravity)
+
+	dynamic := sub(averageAcceleration(accelerating), gravity)
+	horizontal := sub(dynamic, scale(up, dot(dynamic, up)))
+	if magnitude(horizontal) < mountingMinimumAcceleration {
+		return nil, fmt.Errorf("horizontal acceleration of %.3fg is too low, at least %.3fg is required", magnitude(horizontal), mountingMinimumAcceleration)
+	}
+	forward := normalize(horizontal)
+	left := cross(up, forward)
+
+	mounting := &Mounting{
+		Rotation: RotationMatrix{forward, left, up},
+	}
+	mounting.AxisMap = mounting.Rotation.axisMap(mountingAxisAlignmentTolerance)
+	return mounting, nil
+}
+
+// Returns the AxisMap equivalent to the rotation, or nil if one of the
+// camera axes is more than tolerance degrees away from an IMU axis.
+func (r RotationMatrix) axisMap(tolerance float64) *AxisMap {
+	minimum := math.Cos(tolerance * math.Pi / 180)
+	names := []ImuAxis{ImuAxisX, ImuAxisY, ImuAxisZ}
+
+	var axes [3]ImuAxis
+	var inverted [3]bool
+	for camAxis, row := range r {
+		best := 0
+		for imuAxis := range row {
+			if math.Abs(row[imuAxis]) > math.Abs(row[best]) {
+				best = imuAxis
+			}
+		}
+		if math.Abs(row[best]) < minimum {
+			return nil
+		}
+		axes[camAxis] = names[best]
+		inverted[camAxis] = row[best] < 0
+	}
+
+	axisMap, err := ParseAxisMap(string(axes[0]), string(axes[1]), string(axes[2]))
+	if err != nil {
+		return nil
+	}
+	axisMap.SetInvertedAxes(inverted[0], inverted[1], inverted[2])
+	return axisMap
+}
+
+func averageAcceleration(samples []*Acceleration) [3]float64 {
+	var sum [3]float64
+	for _, sample := range samples {
+		sum[0] += sample.X
+		sum[1] += sample.Y
+		sum[2] += sample.Z
+	}
+	return scale(sum, 1/float64(len(samples)))
+}
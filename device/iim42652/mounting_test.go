@@ -0,0 +1,100 @@
+package iim42652
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DetectMounting(t *testing.T) {
+	tilt := 30 * math.Pi / 180
+
+	tests := []struct {
+		name             string
+		stationary       []*Acceleration
+		accelerating     []*Acceleration
+		expectedAxisMap  *AxisMap
+		expectedRotation *RotationMatrix
+		expectedError    bool
+	}{
+		{
+			name:         "default mounting",
+			stationary:   []*Acceleration{{X: 0.01, Y: 1.0}, {X: -0.01, Y: 1.0}},
+			accelerating: []*Acceleration{{Y: 1.0, Z: 0.2}, {Y: 1.0, Z: 0.3}},
+			expectedAxisMap: &AxisMap{
+				CamX: "Z", CamY: "X", CamZ: "Y",
+			},
+		},
+		{
+			name:         "upside down",
+			stationary:   []*Acceleration{{Y: -1.0}},
+			accelerating: []*Acceleration{{Y: -1.0, Z: 0.2}},
+			expectedAxisMap: &AxisMap{
+				CamX: "Z", CamY: "X", CamZ: "Y",
+				InvY: true, InvZ: true,
+			},
+		},
+		{
+			name:         "slightly off axis",
+			stationary:   []*Acceleration{{X: 0.05, Z: 0.99}},
+			accelerating: []*Acceleration{{X: 0.05, Y: -0.2, Z: 0.99}},
+			expectedAxisMap: &AxisMap{
+				CamX: "Y", CamY: "X", CamZ: "Z",
+				InvX: true,
+			},
+		},
+		{
+			name:         "tilted mount",
+			stationary:   []*Acceleration{{X: math.Sin(tilt), Z: math.Cos(tilt)}},
+			accelerating: []*Acceleration{{X: math.Sin(tilt) + 0.2*math.Cos(tilt), Z: math.Cos(tilt) - 0.2*math.Sin(tilt)}},
+			expectedRotation: &RotationMatrix{
+				{math.Cos(tilt), 0, -math.Sin(tilt)},
+				{0, 1, 0},
+				{math.Sin(tilt), 0, math.Cos(tilt)},
+			},
+		},
+		{
+			name:          "moving while stationary",
+			stationary:    []*Acceleration{{Y: 1.5}},
+			accelerating:  []*Acceleration{{Y: 1.0, Z: 0.3}},
+			expectedError: true,
+		},
+		{
+			name:          "no forward acceleration",
+			stationary:    []*Acceleration{{Y: 1.0}},
+			accelerating:  []*Acceleration{{Y: 1.01}},
+			expectedError: true,
+		},
+		{
+			name:          "no samples",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mounting, err := DetectMounting(test.stationary, test.accelerating)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedAxisMap, mounting.AxisMap)
+			if test.expectedRotation != nil {
+				for row := 0; row < 3; row++ {
+					for col := 0; col < 3; col++ {
+						assert.InDelta(t, test.expectedRotation[row][col], mounting.Rotation[row][col], 1e-9)
+					}
+				}
+			}
+
+			up := mounting.Rotation.Apply(averageAcceleration(test.stationary))
+			assert.InDelta(t, 0.0, up[0], 1e-9)
+			assert.InDelta(t, 0.0, up[1], 1e-9)
+			assert.Greater(t, up[2], 0.0)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package iim42652
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedOutputDataRate = errors.New("unsupported output data rate")
+
+// Accelerometer ODR register constants.
+const (
+	bitAccelConfig0ODRPos  byte = 0
+	bitAccelConfig0ODRMask byte = (0x0F << bitAccelConfig0ODRPos)
+)
+
+// Output data rates, in Hz, indexed by the ACCEL_ODR value of ACCEL_CONFIG0
+// and the GYRO_ODR value of GYRO_CONFIG0. 0 marks reserved values.
+var (
+	accelOutputDataRatesHz = []float64{0, 32000, 16000, 8000, 4000, 2000, 1000, 200, 100, 50, 25, 12.5, 6.25, 3.125, 1.5625, 500}
+	gyroOutputDataRatesHz  = []float64{0, 32000, 16000, 8000, 4000, 2000, 1000, 200, 100, 50, 25, 12.5, 0, 0, 0, 500}
+)
+
+// SetAccelerometerOutputDataRate configures the accelerometer ODR, in Hz. It
+// must be one of the rates supported by the device. Init configures 50Hz.
+//
+// Rates above 1kHz are only available in low noise mode, and reading samples
+// through Stream at such rates depends on the SPI bus and the host keeping up.
+func (i *IIM42652) SetAccelerometerOutputDataRate(rate float64) error {
+	odr, err := accelerometerODRSelect(rate)
+	if err != nil {
+		return err
+	}
+
+	err = i.UpdateRegister(RegisterAccelConfig, func(currentValue byte) byte {
+		return currentValue&^bitAccelConfig0ODRMask | odr<<bitAccelConfig0ODRPos
+	})
+	if err != nil {
+		return fmt.Errorf("updating RegisterAccelConfig %q: %w", RegisterAccelConfig, err)
+	}
+	return nil
+}
+
+// AccelerometerOutputDataRate returns the accelerometer ODR, in Hz, currently
+// configured on the device.
+func (i *IIM42652) AccelerometerOutputDataRate() (float64, error) {
+	accelConfig, err := i.ReadRegister(RegisterAccelConfig)
+	if err != nil {
+		return 0, err
+	}
+	return accelerometerOutputDataRate(accelConfig)
+}
+
+func accelerometerOutputDataRate(accelConfig byte) (float64, error) {
+	odr := (accelConfig & bitAccelConfig0ODRMask) >> bitAccelConfig0ODRPos
+	if accelOutputDataRatesHz[odr] == 0 {
+		return 0, fmt.Errorf("reserved accelerometer ODR value %d", odr)
+	}
+	return accelOutputDataRatesHz[odr], nil
+}
+
+// SetGyroOutputDataRate configures the gyro ODR, in Hz. It must be one of the
+// rates supported by the device.
+func (i *IIM42652) SetGyroOutputDataRate(rate float64) error {
+	odr, err := gyroODRSelect(rate)
+	if err != nil {
+		return err
+	}
+
+	err = i.UpdateRegister(RegisterGyroscopeConfig0, func(currentValue byte) byte {
+		return currentValue&^bitGyroConfig0ODRMask | odr<<bitGyroConfig0ODRpos
+	})
+	if err != nil {
+		return fmt.Errorf("updating RegisterGyroscopeConfig0 %q: %w", RegisterGyroscopeConfig0, err)
+	}
+	return nil
+}
+
+// GyroOutputDataRate returns the gyro ODR, in Hz, currently configured on the
+// device.
+func (i *IIM42652) GyroOutputDataRate() (float64, error) {
+	gyroConfig, err := i.ReadRegister(RegisterGyroscopeConfig0)
+	if err != nil {
+		return 0, err
+	}
+	return gyroOutputDataRate(gyroConfig)
+}
+
+func gyroOutputDataRate(gyroConfig byte) (float64, error) {
+	odr := (gyroConfig & bitGyroConfig0ODRMask) >> bitGyroConfig0ODRpos
+	if gyroOutputDataRatesHz[odr] == 0 {
+		return 0, fmt.Errorf("reserved gyro ODR value %d", odr)
+	}
+	return gyroOutputDataRatesHz[odr], nil
+}
+
+func accelerometerODRSelect(rate float64) (byte, error) {
+	for odr, supported := range accelOutputDataRatesHz {
+		if supported != 0 && supported == rate {
+			return byte(odr), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: accelerometer at %vHz, must be one of %v", ErrUnsupportedOutputDataRate, rate, accelOutputDataRatesHz[1:])
+}
+
+func gyroODRSelect(rate float64) (byte, error) {
+	for odr, supported := range gyroOutputDataRatesHz {
+		if supported != 0 && supported == rate {
+			return byte(odr), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: gyro at %vHz, must be one of %v", ErrUnsupportedOutputDataRate, rate, supportedRates(gyroOutputDataRatesHz))
+}
+
+func supportedRates(rates []float64) []float64 {
+	var supported []float64
+	for _, rate := range rates {
+		if rate != 0 {
+			supported = append(supported, rate)
+		}
+	}
+	return supported
+}
@@ -0,0 +1,54 @@
+package iim42652
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AccelerometerOutputDataRate(t *testing.T) {
+	tests := []struct {
+		rate        float64
+		accelConfig byte
+	}{
+		{32000, 0x01},
+		{1000, 0x06},
+		{500, 0x0f},
+		{50, 0x09},
+		{1.5625, 0x0e},
+	}
+
+	for _, test := range tests {
+		odr, err := accelerometerODRSelect(test.rate)
+		require.NoError(t, err)
+		assert.Equal(t, test.accelConfig, odr)
+
+		// The full scale range bits must not interfere.
+		rate, err := accelerometerOutputDataRate(0x60 | test.accelConfig)
+		require.NoError(t, err)
+		assert.Equal(t, test.rate, rate)
+	}
+
+	_, err := accelerometerODRSelect(60)
+	assert.ErrorIs(t, err, ErrUnsupportedOutputDataRate)
+	_, err = accelerometerOutputDataRate(0x60)
+	assert.Error(t, err)
+}
+
+func Test_GyroOutputDataRate(t *testing.T) {
+	rate, err := gyroOutputDataRate(0x06)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, rate)
+
+	_, err = gyroOutputDataRate(0x0c)
+	assert.Error(t, err)
+
+	odr, err := gyroODRSelect(200)
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x07), odr)
+
+	// 6.25Hz is an accelerometer only rate.
+	_, err = gyroODRSelect(6.25)
+	assert.ErrorIs(t, err, ErrUnsupportedOutputDataRate)
+}
@@ -0,0 +1,60 @@
+package iim42652
+
+import "math"
+
+// RotationMatrix rotates a vector expressed along the IMU axes into the
+// camera frame. Each row is a camera axis (X forward, Y left, Z up)
+// expressed in IMU coordinates.
+type RotationMatrix [3][3]float64
+
+func IdentityRotation() RotationMatrix {
+	return RotationMatrix{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+func (r RotationMatrix) Apply(v [3]float64) [3]float64 {
+	return [3]float64{
+		dot(r[0], v),
+		dot(r[1], v),
+		dot(r[2], v),
+	}
+}
+
+func (r RotationMatrix) Transpose() RotationMatrix {
+	return RotationMatrix{
+		{r[0][0], r[1][0], r[2][0]},
+		{r[0][1], r[1][1], r[2][1]},
+		{r[0][2], r[1][2], r[2][2]},
+	}
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func scale(v [3]float64, s float64) [3]float64 {
+	return [3]float64{v[0] * s, v[1] * s, v[2] * s}
+}
+
+func sub(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func magnitude(v [3]float64) float64 {
+	return math.Sqrt(dot(v, v))
+}
+
+func normalize(v [3]float64) [3]float64 {
+	return scale(v, 1/magnitude(v))
+}
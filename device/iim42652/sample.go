@@ -0,0 +1,104 @@
+package iim42652
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Sample groups the acceleration, angular rate and temperature read from the
+// sensor in a single pass.
+type Sample struct {
+	Time           time.Time
+	Acceleration   *Acceleration
+	AngularRate    *AngularRate
+	RawTemperature int16
+	Temperature    float64
+}
+
+// CameraSample is a Sample expressed in the camera frame: X forward, Y left
+// and Z up. Acceleration is in g, angular rate in dps.
+type CameraSample struct {
+	Time         time.Time
+	Acceleration [3]float64
+	AngularRate  [3]float64
+	Temperature  float64
+	// Set when the gyroscope was off, AngularRate is zero then.
+	InvalidAngularRate bool
+}
+
+func (i *IIM42652) GetSample() (*Sample, error) {
+	start := time.Now()
+	sample, err := i.getSample()
+	i.metrics.SampleRead(time.Since(start), err)
+	return sample, err
+}
+
+func (i *IIM42652) getSample() (*Sample, error) {
+	acceleration, err := i.GetAcceleration()
+	if err != nil {
+		return nil, fmt.Errorf("getting acceleration: %w", err)
+	}
+
+	angularRate, err := i.GetGyroscopeData()
+	if err != nil {
+		return nil, fmt.Errorf("getting angular rate: %w", err)
+	}
+
+	rawTemperature, err := i.getRawTemperature()
+	if err != nil {
+		return nil, fmt.Errorf("getting temperature: %w", err)
+	}
+
+	return &Sample{
+		Time:           time.Now(),
+		Acceleration:   acceleration,
+		AngularRate:    angularRate,
+		RawTemperature: rawTemperature,
+		Temperature:    ConvertRawTemperature(rawTemperature),
+	}, nil
+}
+
+// Stream reads a sample every period and sends it to samples until ctx is
+// done or a read fails. Stream never blocks on a slow consumer: when samples
+// is full the sample is dropped. Periods missed because a read took too long
+// are reported to the metrics.
+func (i *IIM42652) Stream(ctx context.Context, period time.Duration, samples chan<- *Sample) error {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	var last time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tick := <-ticker.C:
+			if missed := missedPeriods(last, tick, period); missed > 0 {
+				i.metrics.PeriodsMissed(missed)
+			}
+			last = tick
+		}
+
+		sample, err := i.GetSample()
+		if err != nil {
+			return fmt.Errorf("reading sample: %w", err)
+		}
+
+		select {
+		case samples <- sample:
+			i.metrics.SampleStreamed(sample, false)
+		default:
+			i.metrics.SampleStreamed(sample, true)
+			i.Debugln("sample dropped, consumer is too slow")
+		}
+	}
+}
+
+// missedPeriods returns the number of ticks skipped between last and tick,
+// the ticker drops them when the reader falls behind.
+func missedPeriods(last, tick time.Time, period time.Duration) int {
+	if last.IsZero() {
+		return 0
+	}
+	return int((tick.Sub(last)+period/2)/period) - 1
+}
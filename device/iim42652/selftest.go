@@ -0,0 +1,259 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Self-test register constants.
+const (
+	bitSelfTestConfigAccelPower byte = 0x40
+	bitSelfTestConfigAccelAxes  byte = 0x38
+	bitSelfTestConfigGyroAxes   byte = 0x07
+)
+
+// The self-test runs with the gyro at 250dps and the accelerometer at 4g, both
+// at 1kHz. The limits are those of the InvenSense reference driver.
+const (
+	selfTestSamples       = 200
+	selfTestSettleTime    = 200 * time.Millisecond
+	selfTestGyroFSSelect  = 3
+	selfTestAccelFSSelect = 2
+	selfTestODRSelect     = 6
+
+	selfTestMinRatio = 0.5
+	selfTestMaxRatio = 1.5
+	// Used when the factory response is not programmed.
+	selfTestMinGyroResponseDps    = 60
+	selfTestMinAccelResponseG     = 0.05
+	selfTestMaxAccelResponseG     = 1.2
+	selfTestMaxGyroOffsetDps      = 20
+	selfTestFactoryResponseBase   = 2620
+	selfTestFactoryResponseGrowth = 1.01
+)
+
+// SensorSelfTest holds the self-test results of one sensor, values are in Unit
+// along the IMU axes.
+type SensorSelfTest struct {
+	Sensor string `json:"sensor"`
+	Unit   string `json:"unit"`
+	// Change of the output when the self-test actuation is enabled.
+	Response AxisValues `json:"response"`
+	// Response measured at the factory, 0 on axes where it is not programmed.
+	FactoryResponse AxisValues `json:"factory_response"`
+	Passed          bool       `json:"passed"`
+	Failures        []string   `json:"failures"`
+}
+
+type SelfTestReport struct {
+	Gyro          *SensorSelfTest `json:"gyro"`
+	Accelerometer *SensorSelfTest `json:"accelerometer"`
+	Passed        bool            `json:"passed"`
+}
+
+// SelfTest actuates the gyro and accelerometer and compares their response to
+// the one measured at the factory. The device must be stationary and not
+// streaming, the full scale ranges and output data rates are restored once
+// done.
+func (i *IIM42652) SelfTest() (report *SelfTestReport, err error) {
+	// ReadConfiguration serves the configuration from before the test while
+	// the test settings are applied.
+	configuration, err := i.ReadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("reading configuration: %w", err)
+	}
+	i.setSelfTestConfiguration(configuration)
+	defer i.setSelfTestConfiguration(nil)
+
+	gyroConfig, err := i.ReadRegister(RegisterGyroscopeConfig0)
+	if err != nil {
+		return nil, fmt.Errorf("reading RegisterGyroscopeConfig0 %q: %w", RegisterGyroscopeConfig0, err)
+	}
+	accelConfig, err := i.ReadRegister(RegisterAccelConfig)
+	if err != nil {
+		return nil, fmt.Errorf("reading RegisterAccelConfig %q: %w", RegisterAccelConfig, err)
+	}
+	defer func() {
+		restoreErr := i.restoreSelfTestConfiguration(gyroConfig, accelConfig)
+		if err == nil && restoreErr != nil {
+			report, err = nil, fmt.Errorf("restoring configuration: %w", restoreErr)
+		}
+	}()
+
+	if err := i.WriteRegister(RegisterGyroscopeConfig0, selfTestGyroFSSelect<<bitGyroFsSelectPos|selfTestODRSelect); err != nil {
+		return nil, fmt.Errorf("writing to RegisterGyroscopeConfig0 %q: %w", RegisterGyroscopeConfig0, err)
+	}
+	if err := i.WriteRegister(RegisterAccelConfig, selfTestAccelFSSelect<<bitAccelFsSelectPos|selfTestODRSelect); err != nil {
+		return nil, fmt.Errorf("writing to RegisterAccelConfig %q: %w", RegisterAccelConfig, err)
+	}
+	time.Sleep(selfTestSettleTime)
+
+	gyroOff, err := i.collectGyroStatistics(selfTestSamples)
+	if err != nil {
+		return nil, fmt.Errorf("reading gyro: %w", err)
+	}
+	accelOff, err := i.collectAccelerometerStatistics(selfTestSamples)
+	if err != nil {
+		return nil, fmt.Errorf("reading accelerometer: %w", err)
+	}
+
+	if err := i.enableSelfTest(bitSelfTestConfigGyroAxes); err != nil {
+		return nil, err
+	}
+	gyroOn, err := i.collectGyroStatistics(selfTestSamples)
+	if err != nil {
+		return nil, fmt.Errorf("reading gyro: %w", err)
+	}
+
+	if err := i.enableSelfTest(bitSelfTestConfigAccelPower | bitSelfTestConfigAccelAxes); err != nil {
+		return nil, err
+	}
+	accelOn, err := i.collectAccelerometerStatistics(selfTestSamples)
+	if err != nil {
+		return nil, fmt.Errorf("reading accelerometer: %w", err)
+	}
+
+	if err := i.enableSelfTest(0); err != nil {
+		return nil, err
+	}
+
+	gyroCodes, err := i.readSelfTestData(RegisterGyroSelfTestData)
+	if err != nil {
+		return nil, fmt.Errorf("reading gyro factory response: %w", err)
+	}
+	accelCodes, err := i.readSelfTestData(RegisterAccelSelfTestData)
+	if err != nil {
+		return nil, fmt.Errorf("reading accelerometer factory response: %w", err)
+	}
+
+	for _, stats := range []*rawStatistics{gyroOff, gyroOn, accelOff, accelOn} {
+		if stats.valid() == 0 {
+			return nil, fmt.Errorf("all %d samples were discarded", stats.samples)
+		}
+	}
+	return NewSelfTestReport(
+		evaluateGyroSelfTest(gyroOff.mean(), gyroOn.mean(), gyroCodes),
+		evaluateAccelerometerSelfTest(accelOff.mean(), accelOn.mean(), accelCodes),
+	), nil
+}
+
+// NewSelfTestReport combines the sensor self-tests.
+func NewSelfTestReport(gyro, accelerometer *SensorSelfTest) *SelfTestReport {
+	return &SelfTestReport{
+		Gyro:          gyro,
+		Accelerometer: accelerometer,
+		Passed:        gyro.Passed && accelerometer.Passed,
+	}
+}
+
+func (i *IIM42652) enableSelfTest(config byte) error {
+	if err := i.WriteRegister(RegisterSelfTestConfig, config); err != nil {
+		return fmt.Errorf("writing to RegisterSelfTestConfig %q: %w", RegisterSelfTestConfig, err)
+	}
+	time.Sleep(selfTestSettleTime)
+	return nil
+}
+
+func (i *IIM42652) restoreSelfTestConfiguration(gyroConfig, accelConfig byte) error {
+	if err := i.WriteRegister(RegisterSelfTestConfig, 0); err != nil {
+		return err
+	}
+	if err := i.WriteRegister(RegisterGyroscopeConfig0, gyroConfig); err != nil {
+		return err
+	}
+	if err := i.WriteRegister(RegisterAccelConfig, accelConfig); err != nil {
+		return err
+	}
+	time.Sleep(60 * time.Millisecond)
+	return nil
+}
+
+func (i *IIM42652) readSelfTestData(first *Register) (codes [3]byte, err error) {
+	register := *first
+	for axis := range codes {
+		codes[axis], err = i.ReadRegister(&register)
+		if err != nil {
+			return codes, err
+		}
+		register.Address += 1
+	}
+	return codes, nil
+}
+
+// Raw means are read at the self-test full scale ranges.
+func evaluateGyroSelfTest(off, on [3]float64, codes [3]byte) *SensorSelfTest {
+	fullScale := gyroFullScaleRangesDps[selfTestGyroFSSelect]
+	test := newSensorSelfTest("gyro", "dps", off, on, codes, selfTestGyroFSSelect, fullScale)
+
+	offset := scale(off, fullScale/rawFullScale)
+	for axis, name := range []string{"X", "Y", "Z"} {
+		if codes[axis] == 0 && math.Abs(test.response(axis)) < selfTestMinGyroResponseDps {
+			test.fail(fmt.Sprintf("%s response %.3f below %v", name, test.response(axis), float64(selfTestMinGyroResponseDps)))
+		}
+		if math.Abs(offset[axis]) > selfTestMaxGyroOffsetDps {
+			test.fail(fmt.Sprintf("%s offset %.3f exceeds %v", name, offset[axis], float64(selfTestMaxGyroOffsetDps)))
+		}
+	}
+	return test
+}
+
+func evaluateAccelerometerSelfTest(off, on [3]float64, codes [3]byte) *SensorSelfTest {
+	fullScale := accelFullScaleRangesG[selfTestAccelFSSelect]
+	test := newSensorSelfTest("accelerometer", "g", off, on, codes, selfTestAccelFSSelect, fullScale)
+
+	for axis, name := range []string{"X", "Y", "Z"} {
+		response := math.Abs(test.response(axis))
+		if codes[axis] == 0 && (response < selfTestMinAccelResponseG || response > selfTestMaxAccelResponseG) {
+			test.fail(fmt.Sprintf("%s response %.3f outside [%v, %v]", name, response, selfTestMinAccelResponseG, selfTestMaxAccelResponseG))
+		}
+	}
+	return test
+}
+
+// newSensorSelfTest checks the ratio between the measured and factory
+// responses on the axes where the factory response is programmed.
+func newSensorSelfTest(sensor, unit string, off, on [3]float64, codes [3]byte, fsSelect int, fullScale float64) *SensorSelfTest {
+	response := scale(sub(on, off), fullScale/rawFullScale)
+	var factory [3]float64
+	for axis := range codes {
+		factory[axis] = factorySelfTestResponse(codes[axis], fsSelect) * fullScale / rawFullScale
+	}
+
+	test := &SensorSelfTest{
+		Sensor:          sensor,
+		Unit:            unit,
+		Response:        newAxisValues(response),
+		FactoryResponse: newAxisValues(factory),
+		Passed:          true,
+		Failures:        []string{},
+	}
+	for axis, name := range []string{"X", "Y", "Z"} {
+		if codes[axis] == 0 {
+			continue
+		}
+		ratio := math.Abs(response[axis]) / factory[axis]
+		if ratio < selfTestMinRatio || ratio > selfTestMaxRatio {
+			test.fail(fmt.Sprintf("%s response %.3f is %.2f times the factory one", name, response[axis], ratio))
+		}
+	}
+	return test
+}
+
+// factorySelfTestResponse decodes a ST_DATA register into the response, in
+// LSB, measured at the factory with the fsSelect full scale range.
+func factorySelfTestResponse(code byte, fsSelect int) float64 {
+	if code == 0 {
+		return 0
+	}
+	return selfTestFactoryResponseBase / math.Pow(2, float64(3-fsSelect)) * math.Pow(selfTestFactoryResponseGrowth, float64(code)-1)
+}
+
+func (t *SensorSelfTest) response(axis int) float64 {
+	return [3]float64{t.Response.X, t.Response.Y, t.Response.Z}[axis]
+}
+
+func (t *SensorSelfTest) fail(reason string) {
+	t.Passed = false
+	t.Failures = append(t.Failures, reason)
+}
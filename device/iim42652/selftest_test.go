@@ -0,0 +1,75 @@
+package iim42652
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelfTestEvaluation(t *testing.T) {
+	// At the self-test ranges, 1 dps is 131.072 LSB and 1g 8192 LSB. A code of
+	// 1 is a factory response of 2620 LSB for the gyro and 1310 for the
+	// accelerometer.
+	tests := []struct {
+		name             string
+		gyroOff          [3]float64
+		gyroOn           [3]float64
+		gyroCodes        [3]byte
+		accelOn          [3]float64
+		accelCodes       [3]byte
+		expectedFailures []string
+	}{
+		{
+			name:       "matching factory response",
+			gyroOn:     [3]float64{2620, -2620, 2000},
+			gyroCodes:  [3]byte{1, 1, 1},
+			accelOn:    [3]float64{1310, 1000, -1310},
+			accelCodes: [3]byte{1, 1, 1},
+		},
+		{
+			name:             "weak response",
+			gyroOn:           [3]float64{2620, 1000, 2620},
+			gyroCodes:        [3]byte{1, 1, 1},
+			accelOn:          [3]float64{1310, 1310, 2000},
+			accelCodes:       [3]byte{1, 1, 1},
+			expectedFailures: []string{"Y response 7.629 is 0.38 times the factory one", "Z response 0.244 is 1.53 times the factory one"},
+		},
+		{
+			name:             "gyro offset",
+			gyroOff:          [3]float64{3000, 0, 0},
+			gyroOn:           [3]float64{5620, 2620, 2620},
+			gyroCodes:        [3]byte{1, 1, 1},
+			accelOn:          [3]float64{1310, 1310, 1310},
+			accelCodes:       [3]byte{1, 1, 1},
+			expectedFailures: []string{"X offset 22.888 exceeds 20"},
+		},
+		{
+			name:             "no factory response",
+			gyroOn:           [3]float64{7900, 7900, 2620},
+			accelOn:          [3]float64{1310, 300, 1310},
+			expectedFailures: []string{"Z response 19.989 below 60", "Y response 0.037 outside [0.05, 1.2]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := NewSelfTestReport(
+				evaluateGyroSelfTest(test.gyroOff, test.gyroOn, test.gyroCodes),
+				evaluateAccelerometerSelfTest([3]float64{}, test.accelOn, test.accelCodes),
+			)
+
+			failures := append(report.Gyro.Failures, report.Accelerometer.Failures...)
+			if test.expectedFailures == nil {
+				assert.Empty(t, failures)
+			} else {
+				assert.Equal(t, test.expectedFailures, failures)
+			}
+			assert.Equal(t, len(failures) == 0, report.Passed)
+		})
+	}
+
+	report := evaluateGyroSelfTest([3]float64{}, [3]float64{2620, 0, 0}, [3]byte{1, 0, 0})
+	assert.InDelta(t, 19.99, report.Response.X, 0.01)
+	assert.InDelta(t, 19.99, report.FactoryResponse.X, 0.01)
+	assert.Equal(t, 0.0, report.FactoryResponse.Y)
+}
@@ -3,6 +3,8 @@ package iim42652
 import (
 	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
 	"sync"
 	"time"
 
@@ -24,6 +26,12 @@ type IIM42652 struct {
 
 	debug               bool
 	skipPowerManagement bool
+	metrics             Metrics
+	logOutput           io.Writer
+
+	// Configuration from before the running self-test, see ReadConfiguration.
+	selfTestConfiguration *Configuration
+	selfTestLock          sync.Mutex
 }
 
 func NewSpi(device string, accelerationSensitivity AccelerationSensitivity, gyroScale GyroScale, debug bool, skipPowerManagement bool) *IIM42652 {
@@ -33,20 +41,28 @@ func NewSpi(device string, accelerationSensitivity AccelerationSensitivity, gyro
 		gyroScale:               gyroScale,
 		debug:                   debug,
 		skipPowerManagement:     skipPowerManagement,
+		metrics:                 noopMetrics{},
+		logOutput:               os.Stdout,
 	}
 }
 
+// SetLogOutput sets where the driver logs go, stdout by default. Commands
+// writing their output to stdout send them to stderr instead.
+func (i *IIM42652) SetLogOutput(w io.Writer) {
+	i.logOutput = w
+}
+
 func (i *IIM42652) Init() error {
 	if state, err := host.Init(); err != nil {
 		return fmt.Errorf("failed to initialize driver: %w", err)
 	} else {
-		fmt.Println("driver state:", state)
+		fmt.Fprintln(i.logOutput, "driver state:", state)
 	}
 
 	refs := spireg.All()
-	fmt.Println("SPI ports available:", len(refs))
+	fmt.Fprintln(i.logOutput, "SPI ports available:", len(refs))
 	for _, ref := range refs {
-		fmt.Println("SPI:", ref.Name, ref.Number, ref.Aliases)
+		fmt.Fprintln(i.logOutput, "SPI:", ref.Name, ref.Number, ref.Aliases)
 	}
 	// Use spireg SPI port registry to find the first available SPI bus.
 	p, err := spireg.Open(i.deviceName)
@@ -79,19 +95,19 @@ func (i *IIM42652) Init() error {
 	if err != nil {
 		return fmt.Errorf("getting pwrManagement: %w", err)
 	}
-	fmt.Println("pwrManagement:", hex.EncodeToString([]byte{pwrManagement}))
+	fmt.Fprintln(i.logOutput, "pwrManagement:", hex.EncodeToString([]byte{pwrManagement}))
 
 	deviceConfig, err := i.ReadRegister(RegisterDeviceConfig)
 	if err != nil {
 		return fmt.Errorf("getting deviceConfig: %w", err)
 	}
-	fmt.Println("deviceConfig:", hex.EncodeToString([]byte{deviceConfig}))
+	fmt.Fprintln(i.logOutput, "deviceConfig:", hex.EncodeToString([]byte{deviceConfig}))
 
 	driveConfig, err := i.ReadRegister(RegisterDriveConfig)
 	if err != nil {
 		return fmt.Errorf("getting driveConfig: %w", err)
 	}
-	fmt.Println("driveConfig:", hex.EncodeToString([]byte{driveConfig}))
+	fmt.Fprintln(i.logOutput, "driveConfig:", hex.EncodeToString([]byte{driveConfig}))
 
 	if err := i.SetupSignificantMotionDetection(); err != nil {
 		return fmt.Errorf("setting up significant motion detection: %w", err)
@@ -140,13 +156,34 @@ func (i *IIM42652) SetupPower(pwrMode byte) error {
 		return fmt.Errorf("getting pwrManagement: %w", err)
 	}
 	if pwrManagement == GyroModeLowNoise|AccelerometerModeLowNoise {
-		fmt.Println("IMU devices powered on!")
+		fmt.Fprintln(i.logOutput, "IMU devices powered on!")
 	} else {
 		return fmt.Errorf("failed to power on IMU devices")
 	}
 	return nil
 }
 
+// EnterLowPowerMode turns the gyroscope off and puts the accelerometer in
+// low power mode, the significant motion detection keeps running.
+func (i *IIM42652) EnterLowPowerMode() error {
+	err := i.WriteRegister(RegisterPwrMgmt0, AccelerometerModeLowPower)
+	if err != nil {
+		return fmt.Errorf("entering low power mode: %w", err)
+	}
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+// ExitLowPowerMode turns both sensors back on in low noise mode.
+func (i *IIM42652) ExitLowPowerMode() error {
+	if err := i.SetupPower(GyroModeLowNoise | AccelerometerModeLowNoise); err != nil {
+		return fmt.Errorf("exiting low power mode: %w", err)
+	}
+	// Give the gyroscope time to start, see AngularRate Start-Up Time.
+	time.Sleep(50 * time.Millisecond)
+	return nil
+}
+
 func (i *IIM42652) ResetSignalPath() error {
 	err := i.WriteRegister(RegisterSignalPathReset, 0xFF)
 	if err != nil {
@@ -165,7 +202,7 @@ func (i *IIM42652) setBank(b Bank) error {
 		return nil
 	}
 
-	err := i.connection.Tx([]byte{byte(RegisterBankSel.Address), byte(b)}, nil)
+	err := i.tx(RegisterBankSel, true, []byte{byte(RegisterBankSel.Address), byte(b)}, nil)
 	if err != nil {
 		return fmt.Errorf("setting bank: %w", err)
 	}
@@ -186,7 +223,7 @@ func (i *IIM42652) WriteRegister(reg *Register, value byte) error {
 	}
 
 	msg := []byte{byte(reg.Address), value}
-	if err := i.connection.Tx(msg, nil); err != nil {
+	if err := i.tx(reg, true, msg, nil); err != nil {
 		return fmt.Errorf("writing reg %q: %w", hex.EncodeToString(msg), err)
 	}
 	return nil
@@ -205,7 +242,7 @@ func (i *IIM42652) ReadRegister(reg *Register) (result byte, err error) {
 	msg[0] = ReadMask | byte(reg.Address)
 	r := make([]byte, 2)
 
-	if err := i.connection.Tx(msg, r); err != nil {
+	if err := i.tx(reg, false, msg, r); err != nil {
 		return 0x0, fmt.Errorf("writing to SPI port: %w", err)
 	}
 	result = r[1]
@@ -223,9 +260,9 @@ func (i *IIM42652) UpdateRegister(reg *Register, update func(currentValue byte)
 	if err != nil {
 		return fmt.Errorf("reading from reg %q: %w", reg, err)
 	}
-	fmt.Printf("Read %q: %s\n", reg, hex.EncodeToString([]byte{d}))
+	fmt.Fprintf(i.logOutput, "Read %q: %s\n", reg, hex.EncodeToString([]byte{d}))
 	d = update(d)
-	fmt.Printf("Writing! %q: %s\n", reg, hex.EncodeToString([]byte{d}))
+	fmt.Fprintf(i.logOutput, "Writing! %q: %s\n", reg, hex.EncodeToString([]byte{d}))
 	err = i.WriteRegister(reg, d)
 	if err != nil {
 		return fmt.Errorf("writing to reg %q: %w", reg, err)
@@ -253,12 +290,12 @@ func (i *IIM42652) ReadInt16(regs ...*Register) (int16, error) {
 
 func (i *IIM42652) Debugln(a ...any) {
 	if i.debug {
-		fmt.Println(a...)
+		fmt.Fprintln(i.logOutput, a...)
 	}
 }
 
 func (i *IIM42652) Debugf(format string, a ...any) {
 	if i.debug {
-		fmt.Printf(format, a...)
+		fmt.Fprintf(i.logOutput, format, a...)
 	}
 }
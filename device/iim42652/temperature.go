@@ -10,26 +10,35 @@ func NewTemperature(t float64) Temperature {
 	return temp
 }
 
+// Converts a raw TEMP_DATA reading to degrees Celsius.
+func ConvertRawTemperature(raw int16) float64 {
+	return float64(raw)/132.48 + 25
+}
+
 func (i *IIM42652) GetTemperature() (Temperature, error) {
+	raw, err := i.getRawTemperature()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemperature(ConvertRawTemperature(raw)), nil
+}
+
+func (i *IIM42652) getRawTemperature() (int16, error) {
 	i.registerLock.Lock()
 	defer i.registerLock.Unlock()
 
 	err := i.setBank(RegisterTemperatureData.Bank)
 	if err != nil {
-		return nil, fmt.Errorf("setting bank %s: %w", RegisterTemperatureData.Bank.String(), err)
+		return 0, fmt.Errorf("setting bank %s: %w", RegisterTemperatureData.Bank.String(), err)
 	}
 
 	msg := make([]byte, 7)
 	result := make([]byte, 7)
 	msg[0] = ReadMask | byte(RegisterTemperatureData.Address)
-	if err := i.connection.Tx(msg, result); err != nil {
-		return nil, fmt.Errorf("reading to SPI port: %w", err)
+	if err := i.tx(RegisterTemperatureData, false, msg, result); err != nil {
+		return 0, fmt.Errorf("reading to SPI port: %w", err)
 	}
 
-	temp := int16(result[1])<<8 | int16(result[2])
-	var val *float64
-	val = new(float64)
-	*val = float64(temp)/132.48 + 25
-
-	return val, nil
+	return int16(result[1])<<8 | int16(result[2]), nil
 }
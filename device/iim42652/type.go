@@ -6,6 +6,11 @@ import (
 )
 
 const ShortMax = 32767
+
+// InvalidData is read from the data registers of a sensor that is off, like
+// the gyroscope in low power mode.
+const InvalidData int16 = -32768
+
 const PowerOnSleep = 250
 const ReadMask byte = 0x80
 
@@ -81,6 +86,12 @@ var (
 
 	RegisterOffsetUser0 = &Register{Bank: Bank4, Address: 0x77} // MPUREG_OFFSET_USER_0_B4
 	RegisterOffsetUser4 = &Register{Bank: Bank4, Address: 0x7B} // MPUREG_OFFSET_USER_4_B4
+
+	RegisterSelfTestConfig = &Register{Bank: Bank0, Address: 0x70} // MPUREG_SELF_TEST_CONFIG
+	// XG_ST_DATA (0x5F), YG_ST_DATA (0x60), ZG_ST_DATA (0x61)
+	RegisterGyroSelfTestData = &Register{Bank: Bank1, Address: 0x5F} // MPUREG_XG_ST_DATA_B1
+	// XA_ST_DATA (0x3B), YA_ST_DATA (0x3C), ZA_ST_DATA (0x3D)
+	RegisterAccelSelfTestData = &Register{Bank: Bank2, Address: 0x3B} // MPUREG_XA_ST_DATA_B2
 )
 
 const (
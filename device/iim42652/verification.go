@@ -0,0 +1,205 @@
+package iim42652
+
+import (
+	"fmt"
+	"math"
+)
+
+// VerificationTolerances are the limits a calibrated sensor must stay within
+// to pass verification. The device must be stationary while verifying.
+type VerificationTolerances struct {
+	// Maximum absolute residual gyro bias per axis, in dps.
+	GyroBias float64 `json:"gyro_bias_dps"`
+	// Maximum gyro noise density per axis, in dps/√Hz.
+	GyroNoiseDensity float64 `json:"gyro_noise_density_dps_rthz"`
+	// Noise bandwidth of the gyro signal path, in Hz. CalibrateGyro and
+	// VerifyGyroCalibration configure the gyro UI filter at ODR/10 with a 1kHz ODR.
+	GyroBandwidth float64 `json:"gyro_bandwidth_hz"`
+
+	// Maximum absolute residual accelerometer bias per axis, in g.
+	AccelerationBias float64 `json:"acceleration_bias_g"`
+	// Maximum accelerometer noise density per axis, in g/√Hz.
+	AccelerationNoiseDensity float64 `json:"acceleration_noise_density_g_rthz"`
+	// Noise bandwidth of the accelerometer signal path, in Hz. Init configures
+	// the accelerometer ODR at 50Hz.
+	AccelerationBandwidth float64 `json:"acceleration_bandwidth_hz"`
+	// Magnitude (g) expected from the accelerometer at rest. CalibrateAccelerometer
+	// keeps gravity, use 0 for devices calibrated before it did.
+	ExpectedGravity float64 `json:"expected_gravity_g"`
+	// Maximum difference between the measured and the expected gravity magnitude, in g.
+	GravityError float64 `json:"gravity_error_g"`
+}
+
+// DefaultVerificationTolerances returns the tolerances matching the cutoffs
+// historically used by imucalibrator (7 and 15 LSB at 2000dps and 16g), and
+// noise densities about four times the datasheet typical values.
+func DefaultVerificationTolerances() VerificationTolerances {
+	return VerificationTolerances{
+		GyroBias:                 0.43,
+		GyroNoiseDensity:         0.015,
+		GyroBandwidth:            100,
+		AccelerationBias:         0.0073,
+		AccelerationNoiseDensity: 0.0003,
+		AccelerationBandwidth:    25,
+		ExpectedGravity:          1,
+		GravityError:             0.02,
+	}
+}
+
+type AxisValues struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+func newAxisValues(v [3]float64) AxisValues {
+	return AxisValues{X: v[0], Y: v[1], Z: v[2]}
+}
+
+// SensorVerification holds the verification results of one sensor, values are
+// in Unit along the IMU axes.
+type SensorVerification struct {
+	Sensor       string     `json:"sensor"`
+	Unit         string     `json:"unit"`
+	Samples      int32      `json:"samples"`
+	Discarded    int32      `json:"discarded"`
+	ResidualBias AxisValues `json:"residual_bias"`
+	Noise        AxisValues `json:"noise_rms"`
+	NoiseDensity AxisValues `json:"noise_density"`
+	// Only set for the accelerometer.
+	GravityError *float64 `json:"gravity_error,omitempty"`
+	Passed       bool     `json:"passed"`
+	Failures     []string `json:"failures"`
+}
+
+type VerificationReport struct {
+	Tolerances    VerificationTolerances `json:"tolerances"`
+	Gyro          *SensorVerification    `json:"gyro,omitempty"`
+	Accelerometer *SensorVerification    `json:"accelerometer,omitempty"`
+	Passed        bool                   `json:"passed"`
+}
+
+// VerifyCalibration verifies both sensors and returns the combined report.
+func (i *IIM42652) VerifyCalibration(maxSamples int32, tolerances VerificationTolerances) (*VerificationReport, error) {
+	gyro, err := i.VerifyGyroCalibration(maxSamples, tolerances)
+	if err != nil {
+		return nil, fmt.Errorf("verifying gyro: %w", err)
+	}
+
+	accelerometer, err := i.VerifyAccelerometerCalibration(maxSamples, tolerances)
+	if err != nil {
+		return nil, fmt.Errorf("verifying accelerometer: %w", err)
+	}
+
+	return NewVerificationReport(tolerances, gyro, accelerometer), nil
+}
+
+// NewVerificationReport combines sensor verifications, nil ones are left out.
+func NewVerificationReport(tolerances VerificationTolerances, gyro, accelerometer *SensorVerification) *VerificationReport {
+	report := &VerificationReport{
+		Tolerances:    tolerances,
+		Gyro:          gyro,
+		Accelerometer: accelerometer,
+		Passed:        true,
+	}
+	for _, sensor := range []*SensorVerification{gyro, accelerometer} {
+		if sensor != nil && !sensor.Passed {
+			report.Passed = false
+		}
+	}
+	return report
+}
+
+// VerifyGyroCalibration configures the gyro like CalibrateGyro does, so that
+// the noise is measured in the bandwidth assumed by GyroBandwidth even when the
+// device was not calibrated since its last reset.
+func (i *IIM42652) VerifyGyroCalibration(maxSamples int32, tolerances VerificationTolerances) (*SensorVerification, error) {
+	if err := i.initializeGyroForCalibration(); err != nil {
+		return nil, fmt.Errorf("configuring gyro: %w", err)
+	}
+	stats, err := i.collectGyroStatistics(maxSamples)
+	if err != nil {
+		return nil, err
+	}
+	return verifyGyroStatistics(stats, float64(i.gyroScale), tolerances)
+}
+
+func (i *IIM42652) VerifyAccelerometerCalibration(maxSamples int32, tolerances VerificationTolerances) (*SensorVerification, error) {
+	stats, err := i.collectAccelerometerStatistics(maxSamples)
+	if err != nil {
+		return nil, err
+	}
+	return verifyAccelerometerStatistics(stats, float64(i.accelerationSensitivity), tolerances)
+}
+
+func verifyGyroStatistics(stats *rawStatistics, sensitivity float64, tolerances VerificationTolerances) (*SensorVerification, error) {
+	if stats.valid() == 0 {
+		return nil, fmt.Errorf("all %d samples were discarded", stats.samples)
+	}
+
+	verification := newSensorVerification("gyro", "dps", stats, sensitivity, [3]float64{}, tolerances.GyroBandwidth)
+	verification.check("residual bias", verification.ResidualBias, tolerances.GyroBias)
+	verification.check("noise density", verification.NoiseDensity, tolerances.GyroNoiseDensity)
+	return verification, nil
+}
+
+func verifyAccelerometerStatistics(stats *rawStatistics, sensitivity float64, tolerances VerificationTolerances) (*SensorVerification, error) {
+	if stats.valid() == 0 {
+		return nil, fmt.Errorf("all %d samples were discarded", stats.samples)
+	}
+
+	// When gravity is expected, it is expected along the axis measuring the most of it.
+	mean := scale(stats.mean(), sensitivity)
+	var expected [3]float64
+	if tolerances.ExpectedGravity != 0 {
+		gravityAxis := 0
+		for axis := 1; axis < 3; axis++ {
+			if math.Abs(mean[axis]) > math.Abs(mean[gravityAxis]) {
+				gravityAxis = axis
+			}
+		}
+		expected[gravityAxis] = math.Copysign(tolerances.ExpectedGravity, mean[gravityAxis])
+	}
+
+	verification := newSensorVerification("accelerometer", "g", stats, sensitivity, expected, tolerances.AccelerationBandwidth)
+	verification.check("residual bias", verification.ResidualBias, tolerances.AccelerationBias)
+	verification.check("noise density", verification.NoiseDensity, tolerances.AccelerationNoiseDensity)
+
+	gravityError := magnitude(mean) - tolerances.ExpectedGravity
+	verification.GravityError = &gravityError
+	if math.Abs(gravityError) > tolerances.GravityError {
+		verification.fail(fmt.Sprintf("gravity error %.5f exceeds %.5f", gravityError, tolerances.GravityError))
+	}
+	return verification, nil
+}
+
+func newSensorVerification(sensor, unit string, stats *rawStatistics, sensitivity float64, expected [3]float64, bandwidth float64) *SensorVerification {
+	noise := scale(stats.stdDev(), sensitivity)
+	return &SensorVerification{
+		Sensor:       sensor,
+		Unit:         unit,
+		Samples:      stats.samples,
+		Discarded:    stats.discarded,
+		ResidualBias: newAxisValues(sub(scale(stats.mean(), sensitivity), expected)),
+		Noise:        newAxisValues(noise),
+		NoiseDensity: newAxisValues(scale(noise, 1/math.Sqrt(bandwidth))),
+		Passed:       true,
+		Failures:     []string{},
+	}
+}
+
+func (v *SensorVerification) check(name string, values AxisValues, tolerance float64) {
+	for _, axis := range []struct {
+		name  string
+		value float64
+	}{{"X", values.X}, {"Y", values.Y}, {"Z", values.Z}} {
+		if math.Abs(axis.value) > tolerance {
+			v.fail(fmt.Sprintf("%s %s %.5f exceeds %.5f", axis.name, name, axis.value, tolerance))
+		}
+	}
+}
+
+func (v *SensorVerification) fail(reason string) {
+	v.Passed = false
+	v.Failures = append(v.Failures, reason)
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/detector/impact"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/fusion"
+)
+
+type Type string
+
+const (
+	TypeMotionState Type = "motion_state"
+	TypeDriving     Type = "driving"
+	TypeImpact      Type = "impact"
+)
+
+// Event is the output of one of the detectors, the field matching Type is set.
+// Time is when the event completed: the state change, the end of the driving
+// event or the impact trigger.
+type Event struct {
+	Type        Type                `json:"type"`
+	Time        time.Time           `json:"time"`
+	MotionState *motion.StateChange `json:"motion_state,omitempty"`
+	Driving     *driving.Event      `json:"driving,omitempty"`
+	Impact      *impact.Event       `json:"impact,omitempty"`
+}
+
+type Config struct {
+	Motion motion.Config
+	// Driving events are only detected when set, see driving.LoadConfig.
+	Driving *driving.Config
+	Impact  impact.Config
+	// Time constant of the GravityTracker removing gravity before the driving
+	// event detection.
+	GravityTimeConstant time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Motion:              motion.DefaultConfig(),
+		Impact:              impact.DefaultConfig(),
+		GravityTimeConstant: 10 * time.Second,
+	}
+}
+
+// Processor runs the motion state, driving event and impact detectors on a
+// stream of camera frame samples.
+type Processor struct {
+	classifier *motion.Classifier
+	linear     *fusion.LinearAccelerationFilter
+	driving    *driving.Detector
+	impact     *impact.Detector
+}
+
+func NewProcessor(config *Config) (*Processor, error) {
+	classifier, err := motion.NewClassifier(config.Motion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid motion config: %w", err)
+	}
+	impactDetector, err := impact.NewDetector(config.Impact)
+	if err != nil {
+		return nil, fmt.Errorf("invalid impact config: %w", err)
+	}
+
+	p := &Processor{classifier: classifier, impact: impactDetector}
+	if config.Driving != nil {
+		if p.driving, err = driving.NewDetector(config.Driving); err != nil {
+			return nil, fmt.Errorf("invalid driving config: %w", err)
+		}
+		gravity, err := fusion.NewGravityTracker(config.GravityTimeConstant)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gravity time constant: %w", err)
+		}
+		p.linear = fusion.NewLinearAccelerationFilter(gravity)
+	}
+	return p, nil
+}
+
+func (p *Processor) State() motion.State {
+	return p.classifier.State()
+}
+
+// Process feeds one sample to the detectors and returns the events it
+// completed, if any.
+func (p *Processor) Process(sample *iim42652.CameraSample) []*Event {
+	var events []*Event
+	if change := p.classifier.Process(sample); change != nil {
+		events = append(events, newMotionStateEvent(change))
+	}
+	if p.driving != nil {
+		for _, event := range p.driving.Process(p.linear.Update(sample)) {
+			events = append(events, newDrivingEvent(event))
+		}
+	}
+	if event := p.impact.Process(sample); event != nil {
+		events = append(events, newImpactEvent(event))
+	}
+	return events
+}
+
+// ProcessMotionInterrupt forwards the significant motion interrupt to the
+// motion classifier, see Classifier.ProcessMotionInterrupt.
+func (p *Processor) ProcessMotionInterrupt(t time.Time) []*Event {
+	if change := p.classifier.ProcessMotionInterrupt(t); change != nil {
+		return []*Event{newMotionStateEvent(change)}
+	}
+	return nil
+}
+
+// Flush ends the driving events and impact capture in progress, typically
+// when the stream ends.
+func (p *Processor) Flush() []*Event {
+	var events []*Event
+	if p.driving != nil {
+		for _, event := range p.driving.Flush() {
+			events = append(events, newDrivingEvent(event))
+		}
+	}
+	if event := p.impact.Flush(); event != nil {
+		events = append(events, newImpactEvent(event))
+	}
+	return events
+}
+
+func newMotionStateEvent(change *motion.StateChange) *Event {
+	return &Event{Type: TypeMotionState, Time: change.Time, MotionState: change}
+}
+
+func newDrivingEvent(event *driving.Event) *Event {
+	return &Event{Type: TypeDriving, Time: event.End, Driving: event}
+}
+
+func newImpactEvent(event *impact.Event) *Event {
+	return &Event{Type: TypeImpact, Time: event.TriggerTime(), Impact: event}
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Processor(t *testing.T) {
+	config := DefaultConfig()
+	config.Driving = &driving.Config{
+		ContinuousCountWindow:     10,
+		MinimumMagnitudeThreshold: 0.2,
+		LeftTurnThreshold:         0.15,
+		RightTurnThreshold:        -0.15,
+		AcceleratorThreshold:      0.25,
+		DeceleratorThreshold:      -0.25,
+	}
+	processor, err := NewProcessor(config)
+	require.NoError(t, err)
+
+	// 1s at rest, a 2s left turn, 1s at rest and a 4g impact, at 100Hz.
+	var events []*Event
+	for n := 0; n < 500; n++ {
+		sample := &iim42652.CameraSample{
+			Time:         time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond),
+			Acceleration: [3]float64{0, 0, 1},
+		}
+		if n >= 100 && n < 300 {
+			sample.Acceleration[1] = 0.3
+			sample.AngularRate[2] = 10
+		}
+		if n == 450 {
+			sample.Acceleration = [3]float64{4, 0, 1}
+		}
+		events = append(events, processor.Process(sample)...)
+	}
+	events = append(events, processor.Flush()...)
+
+	var types []Type
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []Type{TypeMotionState, TypeMotionState, TypeDriving, TypeImpact}, types)
+
+	assert.Equal(t, motion.StateStationary, events[0].MotionState.To)
+	assert.Equal(t, motion.StateMoving, events[1].MotionState.To)
+	assert.Equal(t, driving.EventTypeLeftTurn, events[2].Driving.Type)
+	assert.Equal(t, events[2].Driving.End, events[2].Time)
+	assert.Equal(t, time.Unix(0, 0).Add(4500*time.Millisecond), events[3].Time)
+	assert.Equal(t, motion.StateMoving, processor.State())
+}
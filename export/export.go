@@ -0,0 +1,203 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+)
+
+type Format string
+
+const (
+	FormatCSV       Format = "csv"
+	FormatJSONLines Format = "jsonl"
+)
+
+const timeLayout = time.RFC3339Nano
+
+// Row is the exported form of a sample. Raw and scaled values are along the
+// IMU axes, camera values along the camera axes (X forward, Y left, Z up).
+// Accelerations are in g, angular rates in dps and temperatures in °C.
+type Row struct {
+	Time                time.Time `json:"time"`
+	RawAccelerationX    int16     `json:"raw_acceleration_x"`
+	RawAccelerationY    int16     `json:"raw_acceleration_y"`
+	RawAccelerationZ    int16     `json:"raw_acceleration_z"`
+	RawAngularRateX     int16     `json:"raw_angular_rate_x"`
+	RawAngularRateY     int16     `json:"raw_angular_rate_y"`
+	RawAngularRateZ     int16     `json:"raw_angular_rate_z"`
+	RawTemperature      int16     `json:"raw_temperature"`
+	AccelerationX       float64   `json:"acceleration_x"`
+	AccelerationY       float64   `json:"acceleration_y"`
+	AccelerationZ       float64   `json:"acceleration_z"`
+	AngularRateX        float64   `json:"angular_rate_x"`
+	AngularRateY        float64   `json:"angular_rate_y"`
+	AngularRateZ        float64   `json:"angular_rate_z"`
+	Temperature         float64   `json:"temperature"`
+	CameraAccelerationX float64   `json:"camera_acceleration_x"`
+	CameraAccelerationY float64   `json:"camera_acceleration_y"`
+	CameraAccelerationZ float64   `json:"camera_acceleration_z"`
+	CameraAngularRateX  float64   `json:"camera_angular_rate_x"`
+	CameraAngularRateY  float64   `json:"camera_angular_rate_y"`
+	CameraAngularRateZ  float64   `json:"camera_angular_rate_z"`
+}
+
+var csvHeader = []string{
+	"time",
+	"raw_acceleration_x", "raw_acceleration_y", "raw_acceleration_z",
+	"raw_angular_rate_x", "raw_angular_rate_y", "raw_angular_rate_z",
+	"raw_temperature",
+	"acceleration_x", "acceleration_y", "acceleration_z",
+	"angular_rate_x", "angular_rate_y", "angular_rate_z",
+	"temperature",
+	"camera_acceleration_x", "camera_acceleration_y", "camera_acceleration_z",
+	"camera_angular_rate_x", "camera_angular_rate_y", "camera_angular_rate_z",
+}
+
+func NewRow(sample *iim42652.Sample, transform *iim42652.MountTransform) *Row {
+	camera := transform.Sample(sample)
+	return &Row{
+		Time:                sample.Time.UTC(),
+		RawAccelerationX:    sample.Acceleration.RawX,
+		RawAccelerationY:    sample.Acceleration.RawY,
+		RawAccelerationZ:    sample.Acceleration.RawZ,
+		RawAngularRateX:     sample.AngularRate.RawX,
+		RawAngularRateY:     sample.AngularRate.RawY,
+		RawAngularRateZ:     sample.AngularRate.RawZ,
+		RawTemperature:      sample.RawTemperature,
+		AccelerationX:       sample.Acceleration.X,
+		AccelerationY:       sample.Acceleration.Y,
+		AccelerationZ:       sample.Acceleration.Z,
+		AngularRateX:        sample.AngularRate.X,
+		AngularRateY:        sample.AngularRate.Y,
+		AngularRateZ:        sample.AngularRate.Z,
+		Temperature:         sample.Temperature,
+		CameraAccelerationX: camera.Acceleration[0],
+		CameraAccelerationY: camera.Acceleration[1],
+		CameraAccelerationZ: camera.Acceleration[2],
+		CameraAngularRateX:  camera.AngularRate[0],
+		CameraAngularRateY:  camera.AngularRate[1],
+		CameraAngularRateZ:  camera.AngularRate[2],
+	}
+}
+
+func (r *Row) csvRecord() []string {
+	record := []string{r.Time.Format(timeLayout)}
+	for _, raw := range []int16{
+		r.RawAccelerationX, r.RawAccelerationY, r.RawAccelerationZ,
+		r.RawAngularRateX, r.RawAngularRateY, r.RawAngularRateZ,
+		r.RawTemperature,
+	} {
+		record = append(record, strconv.Itoa(int(raw)))
+	}
+	for _, value := range []float64{
+		r.AccelerationX, r.AccelerationY, r.AccelerationZ,
+		r.AngularRateX, r.AngularRateY, r.AngularRateZ,
+		r.Temperature,
+		r.CameraAccelerationX, r.CameraAccelerationY, r.CameraAccelerationZ,
+		r.CameraAngularRateX, r.CameraAngularRateY, r.CameraAngularRateZ,
+	} {
+		record = append(record, strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	return record
+}
+
+// Encoder writes samples in an export format, call Flush once done.
+type Encoder interface {
+	Encode(sample *iim42652.Sample) error
+	Flush() error
+}
+
+func NewEncoder(format Format, w io.Writer, transform *iim42652.MountTransform) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVEncoder(w, transform), nil
+	case FormatJSONLines:
+		return NewJSONLinesEncoder(w, transform), nil
+	default:
+		return nil, fmt.Errorf("format %q not recognized, must be %q or %q", format, FormatCSV, FormatJSONLines)
+	}
+}
+
+// CSVEncoder writes a header line followed by one line per sample.
+type CSVEncoder struct {
+	writer        *csv.Writer
+	transform     *iim42652.MountTransform
+	headerWritten bool
+}
+
+func NewCSVEncoder(w io.Writer, transform *iim42652.MountTransform) *CSVEncoder {
+	return &CSVEncoder{writer: csv.NewWriter(w), transform: transform}
+}
+
+func (e *CSVEncoder) Encode(sample *iim42652.Sample) error {
+	if !e.headerWritten {
+		if err := e.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+	return e.writer.Write(NewRow(sample, e.transform).csvRecord())
+}
+
+func (e *CSVEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// JSONLinesEncoder writes one JSON object per line and sample.
+type JSONLinesEncoder struct {
+	encoder   *json.Encoder
+	transform *iim42652.MountTransform
+}
+
+func NewJSONLinesEncoder(w io.Writer, transform *iim42652.MountTransform) *JSONLinesEncoder {
+	return &JSONLinesEncoder{encoder: json.NewEncoder(w), transform: transform}
+}
+
+func (e *JSONLinesEncoder) Encode(sample *iim42652.Sample) error {
+	return e.encoder.Encode(NewRow(sample, e.transform))
+}
+
+func (e *JSONLinesEncoder) Flush() error {
+	return nil
+}
+
+// TransformFromHeader returns the mounting of a recording, its axis map when
+// the mounting is unknown, or the default axis map.
+func TransformFromHeader(header *recording.Header) (*iim42652.MountTransform, error) {
+	switch {
+	case header.Mounting != nil:
+		return iim42652.NewMountTransform(*header.Mounting)
+	case header.AxisMap != nil:
+		return iim42652.NewMountTransformFromAxisMap(header.AxisMap)
+	default:
+		return iim42652.NewMountTransformFromAxisMap(iim42652.DefaultAxisMap())
+	}
+}
+
+// Convert encodes every sample of a recording and returns how many were
+// written. A truncated last record is skipped.
+func Convert(reader *recording.Reader, encoder Encoder) (int, error) {
+	count := 0
+	for {
+		sample, err := reader.ReadSample()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return count, encoder.Flush()
+		}
+		if err != nil {
+			return count, fmt.Errorf("reading record %d: %w", count, err)
+		}
+		if err := encoder.Encode(sample); err != nil {
+			return count, fmt.Errorf("encoding record %d: %w", count, err)
+		}
+		count++
+	}
+}
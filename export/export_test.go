@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSample() *iim42652.Sample {
+	return &iim42652.Sample{
+		Time:           time.Date(2023, 6, 1, 12, 0, 0, 123456789, time.UTC),
+		Acceleration:   iim42652.NewAcceleration(2048, -4096, 0, iim42652.AccelerationSensitivityG16),
+		AngularRate:    iim42652.NewGyroscope(16, 0, -32, iim42652.GyroScalesG2000),
+		RawTemperature: 1325,
+		Temperature:    iim42652.ConvertRawTemperature(1325),
+	}
+}
+
+func Test_CSVEncoder(t *testing.T) {
+	transform, err := iim42652.NewMountTransformFromAxisMap(iim42652.DefaultAxisMap())
+	require.NoError(t, err)
+
+	buffer := &bytes.Buffer{}
+	encoder, err := NewEncoder(FormatCSV, buffer, transform)
+	require.NoError(t, err)
+	require.NoError(t, encoder.Encode(testSample()))
+	require.NoError(t, encoder.Encode(testSample()))
+	require.NoError(t, encoder.Flush())
+
+	records, err := csv.NewReader(buffer).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, csvHeader, records[0])
+
+	row := map[string]string{}
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	assert.Equal(t, "2023-06-01T12:00:00.123456789Z", row["time"])
+	assert.Equal(t, "2048", row["raw_acceleration_x"])
+	assert.Equal(t, "-32", row["raw_angular_rate_z"])
+	assert.Equal(t, "1325", row["raw_temperature"])
+	// Camera X is IMU Z, camera Y is IMU X and camera Z is IMU Y.
+	assert.Equal(t, row["acceleration_z"], row["camera_acceleration_x"])
+	assert.Equal(t, row["acceleration_x"], row["camera_acceleration_y"])
+	assert.Equal(t, row["acceleration_y"], row["camera_acceleration_z"])
+	assert.Equal(t, row["angular_rate_z"], row["camera_angular_rate_x"])
+	assert.True(t, strings.HasPrefix(row["acceleration_x"], "1.0000"))
+}
+
+func Test_JSONLinesEncoder(t *testing.T) {
+	transform, err := iim42652.NewMountTransformFromAxisMap(iim42652.DefaultAxisMap())
+	require.NoError(t, err)
+
+	buffer := &bytes.Buffer{}
+	encoder, err := NewEncoder(FormatJSONLines, buffer, transform)
+	require.NoError(t, err)
+	require.NoError(t, encoder.Encode(testSample()))
+	require.NoError(t, encoder.Encode(testSample()))
+	require.NoError(t, encoder.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var object map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &object))
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, csvHeader, keys, "CSV and JSON Lines must have the same columns")
+
+	var row Row
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &row))
+	assert.Equal(t, *NewRow(testSample(), transform), row)
+
+	_, err = NewEncoder("xml", buffer, transform)
+	assert.Error(t, err)
+}
+
+func Test_Convert(t *testing.T) {
+	mounting := iim42652.RotationMatrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	buffer := &bytes.Buffer{}
+	writer, err := recording.NewWriter(buffer, &recording.Header{
+		Configuration: &iim42652.Configuration{
+			AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+			GyroScale:               iim42652.GyroScalesG2000,
+		},
+		Mounting: &mounting,
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, writer.WriteSample(testSample()))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := recording.NewReader(buffer)
+	require.NoError(t, err)
+	transform, err := TransformFromHeader(reader.Header())
+	require.NoError(t, err)
+
+	output := &bytes.Buffer{}
+	count, err := Convert(reader, NewJSONLinesEncoder(output, transform))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	var row Row
+	require.NoError(t, json.Unmarshal(bytes.SplitN(output.Bytes(), []byte("\n"), 2)[0], &row))
+	assert.Equal(t, row.AccelerationX, row.CameraAccelerationX, "identity mounting")
+	assert.Equal(t, int16(2048), row.RawAccelerationX)
+}
@@ -0,0 +1,105 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Biquad is a second order IIR filter. The Butterworth designs below use the
+// Audio EQ Cookbook formulas with Q = 1/√2, giving a maximally flat pass band
+// and -3dB at the cutoff.
+type Biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+	sampleRate float64
+
+	s1, s2      float64
+	initialized bool
+}
+
+func NewLowPass(cutoff, sampleRate float64) (*Biquad, error) {
+	if err := validateCutoff(cutoff, sampleRate); err != nil {
+		return nil, err
+	}
+	cos, alpha := butterworth(cutoff, sampleRate)
+	return newBiquad(sampleRate, (1-cos)/2, 1-cos, (1-cos)/2, 1+alpha, -2*cos, 1-alpha), nil
+}
+
+func NewHighPass(cutoff, sampleRate float64) (*Biquad, error) {
+	if err := validateCutoff(cutoff, sampleRate); err != nil {
+		return nil, err
+	}
+	cos, alpha := butterworth(cutoff, sampleRate)
+	return newBiquad(sampleRate, (1+cos)/2, -(1 + cos), (1+cos)/2, 1+alpha, -2*cos, 1-alpha), nil
+}
+
+// NewBandPass cascades a high-pass at low and a low-pass at high. The edges
+// are at -3dB when the band is wide, a narrow band attenuates them more.
+func NewBandPass(low, high, sampleRate float64) (Chain, error) {
+	if low >= high {
+		return nil, fmt.Errorf("low cutoff %vHz must be below high cutoff %vHz", low, high)
+	}
+	highPass, err := NewHighPass(low, sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("low cutoff: %w", err)
+	}
+	lowPass, err := NewLowPass(high, sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("high cutoff: %w", err)
+	}
+	return Chain{highPass, lowPass}, nil
+}
+
+func validateCutoff(cutoff, sampleRate float64) error {
+	if sampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %v", sampleRate)
+	}
+	if cutoff <= 0 || cutoff >= sampleRate/2 {
+		return fmt.Errorf("cutoff must be between 0 and the nyquist frequency %vHz, got %vHz", sampleRate/2, cutoff)
+	}
+	return nil
+}
+
+func butterworth(cutoff, sampleRate float64) (cos, alpha float64) {
+	w0 := 2 * math.Pi * cutoff / sampleRate
+	return math.Cos(w0), math.Sin(w0) / math.Sqrt2
+}
+
+func newBiquad(sampleRate, b0, b1, b2, a0, a1, a2 float64) *Biquad {
+	return &Biquad{b0: b0 / a0, b1: b1 / a0, b2: b2 / a0, a1: a1 / a0, a2: a2 / a0, sampleRate: sampleRate}
+}
+
+func (b *Biquad) Update(x float64) float64 {
+	if !b.initialized {
+		// Start in the steady state of a constant input so that the gravity
+		// in the first sample does not ring through a low-pass.
+		y := x * b.Response(0)
+		b.s2 = b.b2*x - b.a2*y
+		b.s1 = b.b1*x - b.a1*y + b.s2
+		b.initialized = true
+	}
+
+	// Transposed direct form II.
+	y := b.b0*x + b.s1
+	b.s1 = b.b1*x - b.a1*y + b.s2
+	b.s2 = b.b2*x - b.a2*y
+	return y
+}
+
+func (b *Biquad) Reset() {
+	b.s1, b.s2, b.initialized = 0, 0, false
+}
+
+func (b *Biquad) Clone() Filter {
+	return &Biquad{b0: b.b0, b1: b.b1, b2: b.b2, a1: b.a1, a2: b.a2, sampleRate: b.sampleRate}
+}
+
+// Response returns the theoretical gain of the filter at frequency (Hz).
+func (b *Biquad) Response(frequency float64) float64 {
+	z1 := cmplx.Exp(complex(0, -2*math.Pi*frequency/b.sampleRate))
+	z2 := z1 * z1
+	numerator := complex(b.b0, 0) + complex(b.b1, 0)*z1 + complex(b.b2, 0)*z2
+	denominator := 1 + complex(b.a1, 0)*z1 + complex(b.a2, 0)*z2
+	return cmplx.Abs(numerator / denominator)
+}
@@ -0,0 +1,176 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Filter processes one axis of a sample stream. Filters are stateful and
+// expect samples at the rate they were designed for.
+type Filter interface {
+	Update(x float64) float64
+	// Reset forgets the past samples, the next one initializes the filter again.
+	Reset()
+	// Clone returns a reset copy of the filter, used to filter every axis
+	// with the same design.
+	Clone() Filter
+}
+
+// Chain runs the filters one after the other.
+type Chain []Filter
+
+func (c Chain) Update(x float64) float64 {
+	for _, f := range c {
+		x = f.Update(x)
+	}
+	return x
+}
+
+func (c Chain) Reset() {
+	for _, f := range c {
+		f.Reset()
+	}
+}
+
+func (c Chain) Clone() Filter {
+	clone := make(Chain, len(c))
+	for i, f := range c {
+		clone[i] = f.Clone()
+	}
+	return clone
+}
+
+// MovingAverage is the mean of the samples over a sliding window.
+type MovingAverage struct {
+	window []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+func NewMovingAverage(window time.Duration, sampleRate float64) (*MovingAverage, error) {
+	size, err := windowSize(window, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+	return &MovingAverage{window: make([]float64, size)}, nil
+}
+
+func (m *MovingAverage) Update(x float64) float64 {
+	if m.count == len(m.window) {
+		m.sum -= m.window[m.next]
+	} else {
+		m.count++
+	}
+	m.window[m.next] = x
+	m.sum += x
+	m.next = (m.next + 1) % len(m.window)
+	return m.sum / float64(m.count)
+}
+
+func (m *MovingAverage) Reset() {
+	m.next, m.count, m.sum = 0, 0, 0
+}
+
+func (m *MovingAverage) Clone() Filter {
+	return &MovingAverage{window: make([]float64, len(m.window))}
+}
+
+// Exponential is a first order low-pass filter (exponential moving average).
+type Exponential struct {
+	alpha       float64
+	value       float64
+	initialized bool
+}
+
+// NewExponential returns an exponential filter reaching 63% of a step after
+// timeConstant. Its -3dB cutoff is 1/(2π timeConstant).
+func NewExponential(timeConstant time.Duration, sampleRate float64) (*Exponential, error) {
+	if timeConstant <= 0 {
+		return nil, fmt.Errorf("time constant must be positive, got %s", timeConstant)
+	}
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %v", sampleRate)
+	}
+	return &Exponential{alpha: 1 - math.Exp(-1/(timeConstant.Seconds()*sampleRate))}, nil
+}
+
+func (e *Exponential) Update(x float64) float64 {
+	if !e.initialized {
+		e.value = x
+		e.initialized = true
+		return e.value
+	}
+	e.value += e.alpha * (x - e.value)
+	return e.value
+}
+
+func (e *Exponential) Reset() {
+	e.value, e.initialized = 0, false
+}
+
+func (e *Exponential) Clone() Filter {
+	return &Exponential{alpha: e.alpha}
+}
+
+// Median is the median of the samples over a sliding window. It removes
+// spikes shorter than half the window without smearing them like an average.
+type Median struct {
+	window []float64
+	sorted []float64
+	next   int
+	count  int
+}
+
+func NewMedian(window time.Duration, sampleRate float64) (*Median, error) {
+	size, err := windowSize(window, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Median{window: make([]float64, size), sorted: make([]float64, 0, size)}, nil
+}
+
+func (m *Median) Update(x float64) float64 {
+	if m.count == len(m.window) {
+		old := m.window[m.next]
+		i := sort.SearchFloat64s(m.sorted, old)
+		m.sorted = append(m.sorted[:i], m.sorted[i+1:]...)
+	} else {
+		m.count++
+	}
+	m.window[m.next] = x
+	m.next = (m.next + 1) % len(m.window)
+
+	i := sort.SearchFloat64s(m.sorted, x)
+	m.sorted = append(m.sorted, 0)
+	copy(m.sorted[i+1:], m.sorted[i:])
+	m.sorted[i] = x
+
+	n := len(m.sorted)
+	if n%2 == 1 {
+		return m.sorted[n/2]
+	}
+	return (m.sorted[n/2-1] + m.sorted[n/2]) / 2
+}
+
+func (m *Median) Reset() {
+	m.next, m.count = 0, 0
+	m.sorted = m.sorted[:0]
+}
+
+func (m *Median) Clone() Filter {
+	return &Median{window: make([]float64, len(m.window)), sorted: make([]float64, 0, len(m.window))}
+}
+
+func windowSize(window time.Duration, sampleRate float64) (int, error) {
+	if sampleRate <= 0 {
+		return 0, fmt.Errorf("sample rate must be positive, got %v", sampleRate)
+	}
+	size := int(math.Round(window.Seconds() * sampleRate))
+	if size < 1 {
+		return 0, fmt.Errorf("window %s is shorter than a sample at %vHz", window, sampleRate)
+	}
+	return size, nil
+}
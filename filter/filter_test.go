@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleRate = 100
+
+func Test_FrequencyResponse(t *testing.T) {
+	lowPass, err := NewLowPass(5, sampleRate)
+	require.NoError(t, err)
+	highPass, err := NewHighPass(1, sampleRate)
+	require.NoError(t, err)
+	bandPass, err := NewBandPass(0.5, 10, sampleRate)
+	require.NoError(t, err)
+	movingAverage, err := NewMovingAverage(100*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+	exponential, err := NewExponential(80*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		filter    Filter
+		frequency float64
+		expected  float64
+		delta     float64
+	}{
+		{"low-pass pass band", lowPass, 0.5, 1, 0.01},
+		{"low-pass cutoff", lowPass, 5, math.Sqrt2 / 2, 0.01},
+		{"low-pass stop band", lowPass, 25, 0.02, 0.02},
+		{"high-pass stop band", highPass, 0.1, 0.01, 0.01},
+		{"high-pass cutoff", highPass, 1, math.Sqrt2 / 2, 0.01},
+		{"high-pass pass band", highPass, 10, 1, 0.01},
+		{"band-pass below", bandPass, 0.05, 0.01, 0.01},
+		{"band-pass center", bandPass, 2.2, 0.98, 0.02},
+		{"band-pass above", bandPass, 40, 0.01, 0.01},
+		{"moving average pass band", movingAverage, 0.5, 1, 0.01},
+		{"moving average null", movingAverage, 10, 0, 0.01},
+		{"exponential pass band", exponential, 0.1, 1, 0.01},
+		{"exponential cutoff", exponential, 2, math.Sqrt2 / 2, 0.03},
+		{"exponential stop band", exponential, 20, 0.1, 0.02},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.expected, measureGain(test.filter.Clone(), test.frequency), test.delta)
+		})
+	}
+}
+
+func Test_BiquadResponse(t *testing.T) {
+	lowPass, err := NewLowPass(5, sampleRate)
+	require.NoError(t, err)
+	highPass, err := NewHighPass(5, sampleRate)
+	require.NoError(t, err)
+
+	for _, filter := range []*Biquad{lowPass, highPass} {
+		for _, frequency := range []float64{0.5, 2, 5, 10, 30} {
+			assert.InDelta(t, filter.Response(frequency), measureGain(filter.Clone(), frequency), 0.005, "%vHz", frequency)
+		}
+	}
+}
+
+func Test_BiquadStartsSettled(t *testing.T) {
+	lowPass, err := NewLowPass(1, sampleRate)
+	require.NoError(t, err)
+	highPass, err := NewHighPass(1, sampleRate)
+	require.NoError(t, err)
+
+	for n := 0; n < 10; n++ {
+		assert.InDelta(t, 1, lowPass.Update(1), 1e-9)
+		assert.InDelta(t, 0, highPass.Update(1), 1e-9)
+	}
+}
+
+func Test_Median(t *testing.T) {
+	median, err := NewMedian(50*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+
+	input := []float64{1, 1, 1, 9, 1, 1, -7, -7, 1, 1, 5, 5, 5, 5, 5, 5}
+	expected := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 5, 5, 5}
+
+	var output []float64
+	for _, x := range input {
+		output = append(output, median.Update(x))
+	}
+	assert.Equal(t, expected, output)
+}
+
+func Test_Validation(t *testing.T) {
+	_, err := NewLowPass(50, sampleRate)
+	assert.Error(t, err)
+	_, err = NewHighPass(0, sampleRate)
+	assert.Error(t, err)
+	_, err = NewBandPass(10, 5, sampleRate)
+	assert.Error(t, err)
+	_, err = NewMovingAverage(time.Millisecond, sampleRate)
+	assert.Error(t, err)
+	_, err = NewExponential(time.Second, 0)
+	assert.Error(t, err)
+}
+
+// measureGain feeds a sine wave to the filter and returns the ratio of the
+// output amplitude to the input one once the filter has settled. The
+// amplitude is the magnitude of the output DFT bin at the input frequency.
+func measureGain(filter Filter, frequency float64) float64 {
+	period := sampleRate / frequency
+	settle := int(math.Max(10*period, 5*sampleRate))
+	measure := int(math.Round(math.Ceil(2000/period) * period))
+
+	var re, im float64
+	for n := 0; n < settle+measure; n++ {
+		phase := 2 * math.Pi * frequency * float64(n) / sampleRate
+		y := filter.Update(math.Sin(phase))
+		if n >= settle {
+			re += y * math.Cos(phase)
+			im += y * math.Sin(phase)
+		}
+	}
+	return 2 * math.Hypot(re, im) / float64(measure)
+}
@@ -0,0 +1,98 @@
+package filter
+
+import "github.com/streamingfast/imu-controller/device/iim42652"
+
+// Vector applies a copy of the same filter to each of the three axes.
+type Vector [3]Filter
+
+func NewVector(f Filter) Vector {
+	return Vector{f.Clone(), f.Clone(), f.Clone()}
+}
+
+func (v Vector) Update(x [3]float64) [3]float64 {
+	for axis := 0; axis < 3; axis++ {
+		x[axis] = v[axis].Update(x[axis])
+	}
+	return x
+}
+
+func (v Vector) Reset() {
+	for _, f := range v {
+		f.Reset()
+	}
+}
+
+// Stage filters the acceleration and/or the angular rate of camera samples,
+// a nil vector leaves its sensor untouched.
+type Stage struct {
+	Acceleration Vector
+	AngularRate  Vector
+}
+
+// Acceleration returns a stage filtering the acceleration only.
+func Acceleration(f Filter) *Stage {
+	return &Stage{Acceleration: NewVector(f)}
+}
+
+// AngularRate returns a stage filtering the angular rate only.
+func AngularRate(f Filter) *Stage {
+	return &Stage{AngularRate: NewVector(f)}
+}
+
+// Both returns a stage filtering the acceleration and the angular rate with
+// the same design.
+func Both(f Filter) *Stage {
+	return &Stage{Acceleration: NewVector(f), AngularRate: NewVector(f)}
+}
+
+func (s *Stage) process(sample *iim42652.CameraSample) {
+	if s.Acceleration[0] != nil {
+		sample.Acceleration = s.Acceleration.Update(sample.Acceleration)
+	}
+	if s.AngularRate[0] != nil {
+		sample.AngularRate = s.AngularRate.Update(sample.AngularRate)
+	}
+}
+
+func (s *Stage) reset() {
+	if s.Acceleration[0] != nil {
+		s.Acceleration.Reset()
+	}
+	if s.AngularRate[0] != nil {
+		s.AngularRate.Reset()
+	}
+}
+
+// Pipeline chains stages between the driver and the consumers of camera
+// samples, see MountTransform.Sample.
+type Pipeline struct {
+	stages []*Stage
+}
+
+func NewPipeline(stages ...*Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Process returns a filtered copy of sample.
+func (p *Pipeline) Process(sample *iim42652.CameraSample) *iim42652.CameraSample {
+	filtered := *sample
+	for _, stage := range p.stages {
+		stage.process(&filtered)
+	}
+	return &filtered
+}
+
+func (p *Pipeline) Reset() {
+	for _, stage := range p.stages {
+		stage.reset()
+	}
+}
+
+// Run filters the samples received on in and sends them to out until in is
+// closed, it then closes out.
+func (p *Pipeline) Run(in <-chan *iim42652.CameraSample, out chan<- *iim42652.CameraSample) {
+	defer close(out)
+	for sample := range in {
+		out <- p.Process(sample)
+	}
+}
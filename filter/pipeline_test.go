@@ -0,0 +1,52 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pipeline(t *testing.T) {
+	median, err := NewMedian(30*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+	movingAverage, err := NewMovingAverage(20*time.Millisecond, sampleRate)
+	require.NoError(t, err)
+
+	pipeline := NewPipeline(Acceleration(median), AngularRate(movingAverage))
+
+	in := make(chan *iim42652.CameraSample)
+	out := make(chan *iim42652.CameraSample)
+	go pipeline.Run(in, out)
+
+	input := []*iim42652.CameraSample{
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{2, 4, 6}},
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{4, 8, 12}},
+		{Acceleration: [3]float64{9, 1, 2}, AngularRate: [3]float64{4, 8, 12}},
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{4, 8, 12}},
+	}
+	go func() {
+		for _, sample := range input {
+			in <- sample
+		}
+		close(in)
+	}()
+
+	var output []*iim42652.CameraSample
+	for sample := range out {
+		output = append(output, sample)
+	}
+
+	assert.Equal(t, []*iim42652.CameraSample{
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{2, 4, 6}},
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{3, 6, 9}},
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{4, 8, 12}},
+		{Acceleration: [3]float64{0, 1, 2}, AngularRate: [3]float64{4, 8, 12}},
+	}, output)
+	assert.Equal(t, 9.0, input[2].Acceleration[0], "input samples must not be modified")
+
+	pipeline.Reset()
+	assert.Equal(t, [3]float64{9, 1, 2}, pipeline.Process(input[2]).Acceleration)
+}
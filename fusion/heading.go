@@ -0,0 +1,215 @@
+package fusion
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// HeadingConfig describes the gyro errors the heading uncertainty grows with,
+// see the allan package to measure them.
+type HeadingConfig struct {
+	// White noise density of the yaw rate (angle random walk), in dps/√Hz.
+	RandomWalk float64
+	// Standard deviation of the yaw rate bias left after correction, in dps.
+	BiasUncertainty float64
+	// Rate random walk of the bias, in dps·√Hz.
+	RateRandomWalk float64
+	// Relative error of the gyro sensitivity.
+	ScaleFactorError float64
+	// Samples further apart than this are not integrated: the gap grows the
+	// uncertainty and the heading is degraded until the next Correct.
+	MaxSamplePeriod time.Duration
+}
+
+func DefaultHeadingConfig() HeadingConfig {
+	return HeadingConfig{
+		RandomWalk:       0.005,
+		BiasUncertainty:  0.05,
+		RateRandomWalk:   0.0005,
+		ScaleFactorError: 0.005,
+		MaxSamplePeriod:  time.Second,
+	}
+}
+
+func (c HeadingConfig) Validate() error {
+	if c.RandomWalk < 0 || c.BiasUncertainty < 0 || c.RateRandomWalk < 0 || c.ScaleFactorError < 0 {
+		return fmt.Errorf("noise parameters must not be negative")
+	}
+	if c.MaxSamplePeriod <= 0 {
+		return fmt.Errorf("max sample period must be positive, got %s", c.MaxSamplePeriod)
+	}
+	return nil
+}
+
+// Heading is a dead-reckoned heading, in degrees clockwise from north in
+// [0, 360), and its standard deviation.
+type Heading struct {
+	Time        time.Time
+	Heading     float64
+	Uncertainty float64
+	// Set after a gap in the samples, the rotation during the gap is unknown.
+	Degraded bool
+}
+
+// HeadingIntegrator integrates the yaw rate from a known heading, to bridge
+// the gaps between GPS fixes. A positive yaw rate (left turn) decreases the
+// heading.
+//
+// The uncertainty is the one of the last fix or observation, grown with the
+// gyro errors of HeadingConfig since then: the white noise grows it with the
+// square root of the time, the residual bias with the time and the scale
+// factor error with the rotation.
+type HeadingIntegrator struct {
+	config HeadingConfig
+	bias   float64
+
+	initialized bool
+	degraded    bool
+	lastTime    time.Time
+	heading     float64
+
+	// Variance, in deg², at the last correction and the error terms
+	// accumulated since.
+	baseVariance  float64
+	whiteVariance float64
+	elapsed       float64
+	rotation      float64
+}
+
+func NewHeadingIntegrator(config HeadingConfig) (*HeadingIntegrator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &HeadingIntegrator{config: config}, nil
+}
+
+// SetBias sets the yaw rate bias, in dps, removed from every sample. It is
+// the vertical component of the bias in the vehicle frame, when the samples
+// are not already corrected (see GyroBiasTracker.Correct).
+func (h *HeadingIntegrator) SetBias(bias float64) {
+	h.bias = bias
+}
+
+// Initialized reports whether a heading was given with Reset. Until then
+// Update does nothing.
+func (h *HeadingIntegrator) Initialized() bool {
+	return h.initialized
+}
+
+// Reset starts integrating from heading, in degrees, known with the given
+// standard deviation.
+func (h *HeadingIntegrator) Reset(t time.Time, heading, uncertainty float64) {
+	h.initialized = true
+	h.degraded = false
+	h.lastTime = t
+	h.heading = wrapHeading(heading)
+	h.restart(uncertainty * uncertainty)
+}
+
+// Correct blends an external heading observation (GPS course, map matching)
+// of the given standard deviation with the integrated heading, weighting
+// both by their variance. The observation must be at the time of the last
+// update. A degraded heading is replaced by the observation.
+func (h *HeadingIntegrator) Correct(heading, uncertainty float64) *Heading {
+	if !h.initialized || h.degraded {
+		h.Reset(h.lastTime, heading, uncertainty)
+		return h.Heading()
+	}
+
+	variance := h.variance()
+	observationVariance := uncertainty * uncertainty
+	gain := 1.0
+	if variance+observationVariance > 0 {
+		gain = variance / (variance + observationVariance)
+	}
+
+	h.heading = wrapHeading(h.heading + gain*wrapDifference(heading-h.heading))
+	h.restart((1 - gain) * variance)
+	return h.Heading()
+}
+
+func (h *HeadingIntegrator) restart(variance float64) {
+	h.baseVariance = variance
+	h.whiteVariance = 0
+	h.elapsed = 0
+	h.rotation = 0
+}
+
+// Update integrates the yaw rate, in dps, about the earth vertical.
+func (h *HeadingIntegrator) Update(t time.Time, yawRate float64) *Heading {
+	if !h.initialized {
+		h.lastTime = t
+		return nil
+	}
+
+	dt := t.Sub(h.lastTime)
+	if dt <= 0 {
+		return h.Heading()
+	}
+	h.lastTime = t
+	seconds := dt.Seconds()
+	if dt > h.config.MaxSamplePeriod {
+		// The rotation is unknown but the errors kept growing.
+		h.whiteVariance += h.config.RandomWalk * h.config.RandomWalk * seconds
+		h.elapsed += seconds
+		h.degraded = true
+		return h.Heading()
+	}
+
+	delta := (yawRate - h.bias) * seconds
+	h.heading = wrapHeading(h.heading - delta)
+
+	h.whiteVariance += h.config.RandomWalk * h.config.RandomWalk * seconds
+	h.elapsed += seconds
+	h.rotation += math.Abs(delta)
+	return h.Heading()
+}
+
+// UpdateSample integrates the yaw rate of a camera sample. The camera Z axis
+// is taken as the vertical, use YawRate and Update when the vehicle is tilted.
+func (h *HeadingIntegrator) UpdateSample(sample *iim42652.CameraSample) *Heading {
+	return h.Update(sample.Time, sample.AngularRate[2])
+}
+
+func (h *HeadingIntegrator) Heading() *Heading {
+	return &Heading{
+		Time:        h.lastTime,
+		Heading:     h.heading,
+		Uncertainty: math.Sqrt(h.variance()),
+		Degraded:    h.degraded,
+	}
+}
+
+func (h *HeadingIntegrator) variance() float64 {
+	bias := h.config.BiasUncertainty * h.elapsed
+	scaleFactor := h.config.ScaleFactorError * h.rotation
+	rateRandomWalk := h.config.RateRandomWalk * h.config.RateRandomWalk * h.elapsed * h.elapsed * h.elapsed / 3
+	return h.baseVariance + h.whiteVariance + bias*bias + scaleFactor*scaleFactor + rateRandomWalk
+}
+
+// YawRate returns the rotation rate about the earth vertical, in dps, from
+// the angular rate and the gravity (see GravityFromOrientation) in the
+// camera frame.
+func YawRate(angularRate, gravity [3]float64) float64 {
+	norm := magnitude(gravity)
+	if norm == 0 {
+		return angularRate[2]
+	}
+	return (angularRate[0]*gravity[0] + angularRate[1]*gravity[1] + angularRate[2]*gravity[2]) / norm
+}
+
+func wrapHeading(heading float64) float64 {
+	heading = math.Mod(heading, 360)
+	if heading < 0 {
+		heading += 360
+	}
+	return heading
+}
+
+// wrapDifference brings an angle difference in [-180, 180).
+func wrapDifference(difference float64) float64 {
+	return wrapHeading(difference+180) - 180
+}
@@ -0,0 +1,156 @@
+package fusion
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HeadingIntegrator(t *testing.T) {
+	tests := []struct {
+		name                string
+		config              func(c *HeadingConfig)
+		initialHeading      float64
+		bias                float64
+		yawRate             func(n int) float64
+		count               int
+		expectedHeading     float64
+		expectedUncertainty float64
+	}{
+		{
+			name:                "left turn",
+			initialHeading:      10,
+			yawRate:             func(n int) float64 { return 9 },
+			count:               1000,
+			expectedHeading:     280,
+			expectedUncertainty: math.Sqrt(1 + 0.45*0.45),
+			config:              func(c *HeadingConfig) { *c = HeadingConfig{ScaleFactorError: 0.005, MaxSamplePeriod: time.Second} },
+		},
+		{
+			name:                "right turn across north",
+			initialHeading:      300,
+			yawRate:             func(n int) float64 { return -9 },
+			count:               1000,
+			expectedHeading:     30,
+			expectedUncertainty: 1,
+			config:              func(c *HeadingConfig) { *c = HeadingConfig{MaxSamplePeriod: time.Second} },
+		},
+		{
+			name:                "bias removed",
+			initialHeading:      90,
+			bias:                0.3,
+			yawRate:             func(n int) float64 { return 0.3 },
+			count:               10000,
+			expectedHeading:     90,
+			expectedUncertainty: math.Sqrt(1 + 5*5),
+			config:              func(c *HeadingConfig) { *c = HeadingConfig{BiasUncertainty: 0.05, MaxSamplePeriod: time.Second} },
+		},
+		{
+			name:                "white noise",
+			initialHeading:      90,
+			yawRate:             func(n int) float64 { return 0 },
+			count:               10000,
+			expectedHeading:     90,
+			expectedUncertainty: math.Sqrt(1 + 0.01*0.01*100),
+			config:              func(c *HeadingConfig) { *c = HeadingConfig{RandomWalk: 0.01, MaxSamplePeriod: time.Second} },
+		},
+		{
+			name:                "gap is not integrated",
+			initialHeading:      90,
+			yawRate:             func(n int) float64 { return 10 },
+			count:               2,
+			expectedHeading:     90,
+			expectedUncertainty: math.Sqrt(1 + 0.01*0.01*0.02 + 0.001*0.001),
+			config: func(c *HeadingConfig) {
+				*c = HeadingConfig{RandomWalk: 0.01, BiasUncertainty: 0.05, MaxSamplePeriod: 5 * time.Millisecond}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultHeadingConfig()
+			test.config(&config)
+			integrator, err := NewHeadingIntegrator(config)
+			require.NoError(t, err)
+
+			assert.Nil(t, integrator.Update(at(0), 1))
+			integrator.Reset(at(0), test.initialHeading, 1)
+			integrator.SetBias(test.bias)
+
+			var heading *Heading
+			for n := 1; n <= test.count; n++ {
+				heading = integrator.Update(at(n), test.yawRate(n))
+			}
+
+			assert.InDelta(t, test.expectedHeading, heading.Heading, 1e-6)
+			assert.InDelta(t, test.expectedUncertainty, heading.Uncertainty, 1e-6)
+		})
+	}
+}
+
+func Test_HeadingCorrection(t *testing.T) {
+	tests := []struct {
+		name                string
+		heading             float64
+		observation         float64
+		observationError    float64
+		expectedHeading     float64
+		expectedUncertainty float64
+	}{
+		{"equal weights", 100, 110, 3, 105, math.Sqrt(4.5)},
+		{"across north", 350, 10, 3, 0, math.Sqrt(4.5)},
+		{"precise observation", 100, 110, 0, 110, 0},
+		{"poor observation", 100, 130, 9, 103, 3 * 9 / math.Sqrt(90)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			integrator, err := NewHeadingIntegrator(DefaultHeadingConfig())
+			require.NoError(t, err)
+			integrator.Reset(at(0), test.heading, 3)
+
+			heading := integrator.Correct(test.observation, test.observationError)
+			assert.InDelta(t, test.expectedHeading, heading.Heading, 1e-6)
+			assert.InDelta(t, test.expectedUncertainty, heading.Uncertainty, 1e-6)
+		})
+	}
+}
+
+func Test_HeadingGap(t *testing.T) {
+	integrator, err := NewHeadingIntegrator(DefaultHeadingConfig())
+	require.NoError(t, err)
+	integrator.Reset(at(0), 100, 1)
+	assert.False(t, integrator.Update(at(1), 0).Degraded)
+
+	heading := integrator.Update(at(1000), 0)
+	assert.True(t, heading.Degraded)
+	assert.Greater(t, heading.Uncertainty, 1.0)
+
+	// The next observation is taken as is, whatever the uncertainties.
+	heading = integrator.Correct(150, 5)
+	assert.False(t, heading.Degraded)
+	assert.InDelta(t, 150, heading.Heading, 1e-6)
+	assert.InDelta(t, 5, heading.Uncertainty, 1e-6)
+
+	heading = integrator.Correct(160, 5)
+	assert.InDelta(t, 155, heading.Heading, 1e-6)
+}
+
+func Test_YawRate(t *testing.T) {
+	// Nose up 30 degrees, turning left at 10 dps: the gyro sees the rotation
+	// split between its X and Z axes.
+	pitch := -30 * degreesToRadians
+	gravity := GravityFromOrientation(QuaternionFromEuler(0, pitch, 0))
+	angularRate := [3]float64{-10 * math.Sin(pitch), 0, 10 * math.Cos(pitch)}
+
+	assert.InDelta(t, 10, YawRate(angularRate, gravity), 1e-9)
+	assert.InDelta(t, 5, YawRate([3]float64{0, 0, 5}, [3]float64{}), 1e-9)
+}
+
+func at(n int) time.Time {
+	return time.Unix(0, int64(n)*int64(10*time.Millisecond))
+}
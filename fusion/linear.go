@@ -0,0 +1,117 @@
+package fusion
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// LinearAcceleration is the dynamic acceleration of the vehicle, in g, once
+// gravity is removed. Axes follow the vehicle (camera) frame: Forward is
+// positive when accelerating, Lateral is positive to the left (left turn) and
+// Vertical is positive up.
+type LinearAcceleration struct {
+	Time     time.Time
+	Forward  float64
+	Lateral  float64
+	Vertical float64
+}
+
+func (l *LinearAcceleration) Magnitude() float64 {
+	return magnitude([3]float64{l.Forward, l.Lateral, l.Vertical})
+}
+
+// GravityEstimator estimates the gravity vector in the camera frame, in g.
+type GravityEstimator interface {
+	Update(sample *iim42652.CameraSample) [3]float64
+}
+
+// OrientationGravity estimates gravity from the orientation computed by a
+// Madgwick filter. The filter is updated with every sample.
+type OrientationGravity struct {
+	filter *Madgwick
+}
+
+func NewOrientationGravity(filter *Madgwick) *OrientationGravity {
+	return &OrientationGravity{filter: filter}
+}
+
+func (o *OrientationGravity) Update(sample *iim42652.CameraSample) [3]float64 {
+	orientation := o.filter.Update(sample)
+	return GravityFromOrientation(orientation.Quaternion)
+}
+
+// GravityFromOrientation returns the acceleration measured at rest (1g up in
+// the earth frame) expressed in the camera frame.
+func GravityFromOrientation(q Quaternion) [3]float64 {
+	return q.Conjugate().Rotate([3]float64{0, 0, 1})
+}
+
+// GravityTracker estimates gravity with a first order low-pass filter on the
+// acceleration, for when no orientation is available. It tracks whatever
+// acceleration persists longer than the time constant, so it also absorbs the
+// slow drift of the accelerometer bias, but a long sustained acceleration is
+// partially absorbed too.
+type GravityTracker struct {
+	timeConstant time.Duration
+	gravity      [3]float64
+	lastTime     time.Time
+	initialized  bool
+}
+
+func NewGravityTracker(timeConstant time.Duration) (*GravityTracker, error) {
+	if timeConstant <= 0 {
+		return nil, fmt.Errorf("time constant must be positive, got %s", timeConstant)
+	}
+	return &GravityTracker{timeConstant: timeConstant}, nil
+}
+
+func (g *GravityTracker) Update(sample *iim42652.CameraSample) [3]float64 {
+	if !g.initialized {
+		g.gravity = sample.Acceleration
+		g.lastTime = sample.Time
+		g.initialized = true
+		return g.gravity
+	}
+
+	dt := sample.Time.Sub(g.lastTime)
+	if dt <= 0 {
+		return g.gravity
+	}
+	g.lastTime = sample.Time
+
+	alpha := 1 - math.Exp(-dt.Seconds()/g.timeConstant.Seconds())
+	for axis := 0; axis < 3; axis++ {
+		g.gravity[axis] += alpha * (sample.Acceleration[axis] - g.gravity[axis])
+	}
+	return g.gravity
+}
+
+func (g *GravityTracker) Gravity() [3]float64 {
+	return g.gravity
+}
+
+// LinearAccelerationFilter removes the gravity estimated by a
+// GravityEstimator from the samples.
+type LinearAccelerationFilter struct {
+	gravity GravityEstimator
+}
+
+func NewLinearAccelerationFilter(gravity GravityEstimator) *LinearAccelerationFilter {
+	return &LinearAccelerationFilter{gravity: gravity}
+}
+
+func (l *LinearAccelerationFilter) Update(sample *iim42652.CameraSample) *LinearAcceleration {
+	return RemoveGravity(sample, l.gravity.Update(sample))
+}
+
+func RemoveGravity(sample *iim42652.CameraSample, gravity [3]float64) *LinearAcceleration {
+	return &LinearAcceleration{
+		Time:     sample.Time,
+		Forward:  sample.Acceleration[0] - gravity[0],
+		Lateral:  sample.Acceleration[1] - gravity[1],
+		Vertical: sample.Acceleration[2] - gravity[2],
+	}
+}
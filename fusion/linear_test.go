@@ -0,0 +1,90 @@
+package fusion
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LinearAccelerationFilter(t *testing.T) {
+	hill := 10 * degreesToRadians
+	onHill := [3]float64{-math.Sin(hill), 0, math.Cos(hill)}
+
+	newOrientationGravity := func(t *testing.T) GravityEstimator {
+		filter, err := NewMadgwick(DefaultConfig())
+		require.NoError(t, err)
+		return NewOrientationGravity(filter)
+	}
+	newGravityTracker := func(t *testing.T) GravityEstimator {
+		tracker, err := NewGravityTracker(10 * time.Second)
+		require.NoError(t, err)
+		return tracker
+	}
+
+	tests := []struct {
+		name     string
+		gravity  func(t *testing.T) GravityEstimator
+		samples  func(n int) [3]float64
+		count    int
+		expected LinearAcceleration
+	}{
+		{
+			name:    "orientation on a hill",
+			gravity: newOrientationGravity,
+			samples: func(n int) [3]float64 { return onHill },
+			count:   100,
+		},
+		{
+			name:    "tracker on a hill",
+			gravity: newGravityTracker,
+			samples: func(n int) [3]float64 { return onHill },
+			count:   100,
+		},
+		{
+			name:    "tracker braking on a hill",
+			gravity: newGravityTracker,
+			samples: func(n int) [3]float64 {
+				if n < 1000 {
+					return onHill
+				}
+				return [3]float64{onHill[0] - 0.3, 0, onHill[2]}
+			},
+			count:    1001,
+			expected: LinearAcceleration{Forward: -0.3},
+		},
+		{
+			name:    "tracker with gravity cancelled by calibration",
+			gravity: newGravityTracker,
+			samples: func(n int) [3]float64 {
+				if n < 1000 {
+					return [3]float64{}
+				}
+				return [3]float64{0, 0.2, 0}
+			},
+			count:    1001,
+			expected: LinearAcceleration{Lateral: 0.2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := NewLinearAccelerationFilter(test.gravity(t))
+
+			var linear *LinearAcceleration
+			for n := 0; n < test.count; n++ {
+				linear = filter.Update(&iim42652.CameraSample{
+					Time:         time.Unix(0, int64(n)*int64(10*time.Millisecond)),
+					Acceleration: test.samples(n),
+				})
+			}
+
+			assert.InDelta(t, test.expected.Forward, linear.Forward, 0.005)
+			assert.InDelta(t, test.expected.Lateral, linear.Lateral, 0.005)
+			assert.InDelta(t, test.expected.Vertical, linear.Vertical, 0.005)
+		})
+	}
+}
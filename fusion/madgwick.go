@@ -0,0 +1,166 @@
+package fusion
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+const degreesToRadians = math.Pi / 180
+
+type Config struct {
+	// Gain of the accelerometer correction, in rad/s. Higher values trust the
+	// accelerometer more and converge faster but let vehicle accelerations
+	// leak into roll and pitch.
+	Beta float64
+	// Samples further apart than this only reset the filter clock, the
+	// angular rate is not integrated over the gap.
+	MaxSamplePeriod time.Duration
+	// Accelerometer readings whose magnitude is more than this (g) away from
+	// 1g are not used for the correction, the filter then relies on the gyro
+	// alone.
+	AccelerationRejection float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Beta:                  0.1,
+		MaxSamplePeriod:       time.Second,
+		AccelerationRejection: 0.15,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Beta < 0 {
+		return fmt.Errorf("beta must be positive, got %v", c.Beta)
+	}
+	if c.MaxSamplePeriod <= 0 {
+		return fmt.Errorf("max sample period must be positive, got %s", c.MaxSamplePeriod)
+	}
+	return nil
+}
+
+// Orientation of the camera frame relative to the earth frame. Angles are
+// in degrees, see Quaternion.Euler for the conventions.
+type Orientation struct {
+	Time       time.Time
+	Quaternion Quaternion
+	Roll       float64
+	Pitch      float64
+	Yaw        float64
+}
+
+func newOrientation(t time.Time, q Quaternion) *Orientation {
+	roll, pitch, yaw := q.Euler()
+	return &Orientation{
+		Time:       t,
+		Quaternion: q,
+		Roll:       roll / degreesToRadians,
+		Pitch:      pitch / degreesToRadians,
+		Yaw:        yaw / degreesToRadians,
+	}
+}
+
+// Madgwick is the IMU (accelerometer and gyroscope) variant of Madgwick's
+// gradient descent orientation filter. It consumes camera frame samples and
+// derives the sample period from their timestamps, so feeding it recorded
+// samples gives the same result as running it live.
+//
+// The accelerometer must measure gravity, CalibrateAccelerometer only removes
+// the bias and keeps it.
+type Madgwick struct {
+	config      Config
+	q           Quaternion
+	lastTime    time.Time
+	initialized bool
+}
+
+func NewMadgwick(config Config) (*Madgwick, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Madgwick{config: config, q: IdentityQuaternion()}, nil
+}
+
+// Reset forgets the current orientation, the next sample initializes it again.
+func (m *Madgwick) Reset() {
+	m.q = IdentityQuaternion()
+	m.lastTime = time.Time{}
+	m.initialized = false
+}
+
+func (m *Madgwick) Orientation() *Orientation {
+	return newOrientation(m.lastTime, m.q)
+}
+
+func (m *Madgwick) Update(sample *iim42652.CameraSample) *Orientation {
+	accel := sample.Acceleration
+	accelValid := math.Abs(magnitude(accel)-1) <= m.config.AccelerationRejection
+
+	if !m.initialized {
+		// Start from the roll and pitch given by gravity rather than waiting
+		// for the filter to converge from level.
+		if accelValid {
+			roll := math.Atan2(accel[1], accel[2])
+			pitch := math.Atan2(-accel[0], math.Hypot(accel[1], accel[2]))
+			m.q = QuaternionFromEuler(roll, pitch, 0)
+		}
+		m.lastTime = sample.Time
+		m.initialized = true
+		return m.Orientation()
+	}
+
+	dt := sample.Time.Sub(m.lastTime)
+	if dt <= 0 {
+		return m.Orientation()
+	}
+	m.lastTime = sample.Time
+	if dt > m.config.MaxSamplePeriod {
+		return m.Orientation()
+	}
+
+	gx := sample.AngularRate[0] * degreesToRadians
+	gy := sample.AngularRate[1] * degreesToRadians
+	gz := sample.AngularRate[2] * degreesToRadians
+	q0, q1, q2, q3 := m.q.W, m.q.X, m.q.Y, m.q.Z
+
+	// Rate of change of the quaternion from the gyroscope.
+	qDot0 := 0.5 * (-q1*gx - q2*gy - q3*gz)
+	qDot1 := 0.5 * (q0*gx + q2*gz - q3*gy)
+	qDot2 := 0.5 * (q0*gy - q1*gz + q3*gx)
+	qDot3 := 0.5 * (q0*gz + q1*gy - q2*gx)
+
+	if accelValid {
+		norm := magnitude(accel)
+		ax, ay, az := accel[0]/norm, accel[1]/norm, accel[2]/norm
+
+		// Gradient of the error between the measured gravity direction and
+		// the one predicted by the current orientation.
+		s0 := 4*q0*q2*q2 + 2*q2*ax + 4*q0*q1*q1 - 2*q1*ay
+		s1 := 4*q1*q3*q3 - 2*q3*ax + 4*q0*q0*q1 - 2*q0*ay - 4*q1 + 8*q1*q1*q1 + 8*q1*q2*q2 + 4*q1*az
+		s2 := 4*q0*q0*q2 + 2*q0*ax + 4*q2*q3*q3 - 2*q3*ay - 4*q2 + 8*q2*q1*q1 + 8*q2*q2*q2 + 4*q2*az
+		s3 := 4*q1*q1*q3 - 2*q1*ax + 4*q2*q2*q3 - 2*q2*ay
+		if sNorm := math.Sqrt(s0*s0 + s1*s1 + s2*s2 + s3*s3); sNorm > 0 {
+			qDot0 -= m.config.Beta * s0 / sNorm
+			qDot1 -= m.config.Beta * s1 / sNorm
+			qDot2 -= m.config.Beta * s2 / sNorm
+			qDot3 -= m.config.Beta * s3 / sNorm
+		}
+	}
+
+	seconds := dt.Seconds()
+	m.q = Quaternion{
+		W: q0 + qDot0*seconds,
+		X: q1 + qDot1*seconds,
+		Y: q2 + qDot2*seconds,
+		Z: q3 + qDot3*seconds,
+	}.Normalize()
+
+	return m.Orientation()
+}
+
+func magnitude(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
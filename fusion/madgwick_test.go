@@ -0,0 +1,155 @@
+package fusion
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Madgwick(t *testing.T) {
+	tilt := 30 * degreesToRadians
+	level := [3]float64{0, 0, 1}
+
+	tests := []struct {
+		name          string
+		samples       func(n int) (acceleration [3]float64, angularRate [3]float64)
+		count         int
+		period        time.Duration
+		expectedRoll  float64
+		expectedPitch float64
+		expectedYaw   float64
+	}{
+		{
+			name: "level",
+			samples: func(n int) ([3]float64, [3]float64) {
+				return level, [3]float64{}
+			},
+			count: 100,
+		},
+		{
+			name: "nose down",
+			samples: func(n int) ([3]float64, [3]float64) {
+				return [3]float64{-math.Sin(tilt), 0, math.Cos(tilt)}, [3]float64{}
+			},
+			count:         100,
+			expectedPitch: 30,
+		},
+		{
+			name: "right side down",
+			samples: func(n int) ([3]float64, [3]float64) {
+				return [3]float64{0, math.Sin(tilt), math.Cos(tilt)}, [3]float64{}
+			},
+			count:        100,
+			expectedRoll: 30,
+		},
+		{
+			name: "converges from level to tilted",
+			samples: func(n int) ([3]float64, [3]float64) {
+				if n == 0 {
+					return level, [3]float64{}
+				}
+				return [3]float64{-math.Sin(tilt), 0, math.Cos(tilt)}, [3]float64{}
+			},
+			count:         3000,
+			expectedPitch: 30,
+		},
+		{
+			name: "left turn",
+			samples: func(n int) ([3]float64, [3]float64) {
+				return level, [3]float64{0, 0, 45}
+			},
+			count:       201,
+			expectedYaw: 90,
+		},
+		{
+			name: "right turn at 50Hz",
+			samples: func(n int) ([3]float64, [3]float64) {
+				return level, [3]float64{0, 0, -45}
+			},
+			count:       101,
+			period:      20 * time.Millisecond,
+			expectedYaw: -90,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := NewMadgwick(DefaultConfig())
+			require.NoError(t, err)
+
+			period := test.period
+			if period == 0 {
+				period = 10 * time.Millisecond
+			}
+
+			var orientation *Orientation
+			now := time.Unix(0, 0)
+			for n := 0; n < test.count; n++ {
+				acceleration, angularRate := test.samples(n)
+				orientation = filter.Update(&iim42652.CameraSample{
+					Time:         now,
+					Acceleration: acceleration,
+					AngularRate:  angularRate,
+				})
+				now = now.Add(period)
+			}
+
+			assert.InDelta(t, test.expectedRoll, orientation.Roll, 0.5)
+			assert.InDelta(t, test.expectedPitch, orientation.Pitch, 0.5)
+			assert.InDelta(t, test.expectedYaw, orientation.Yaw, 0.5)
+			assert.InDelta(t, 1.0, orientation.Quaternion.Norm(), 1e-9)
+		})
+	}
+}
+
+func Test_MadgwickDeterministic(t *testing.T) {
+	run := func() *Orientation {
+		filter, err := NewMadgwick(DefaultConfig())
+		require.NoError(t, err)
+
+		var orientation *Orientation
+		for n := 0; n < 500; n++ {
+			orientation = filter.Update(&iim42652.CameraSample{
+				Time:         time.Unix(0, int64(n)*int64(10*time.Millisecond)),
+				Acceleration: [3]float64{0.1 * math.Sin(float64(n)/10), 0.05, 1},
+				AngularRate:  [3]float64{1, -2, 10 * math.Cos(float64(n)/50)},
+			})
+		}
+		return orientation
+	}
+
+	assert.Equal(t, run(), run())
+}
+
+func Test_MadgwickGap(t *testing.T) {
+	filter, err := NewMadgwick(DefaultConfig())
+	require.NoError(t, err)
+
+	filter.Update(&iim42652.CameraSample{Time: time.Unix(0, 0), Acceleration: [3]float64{0, 0, 1}})
+	orientation := filter.Update(&iim42652.CameraSample{
+		Time:         time.Unix(10, 0),
+		Acceleration: [3]float64{0, 0, 1},
+		AngularRate:  [3]float64{0, 0, 90},
+	})
+
+	assert.InDelta(t, 0.0, orientation.Yaw, 1e-9)
+	assert.Equal(t, time.Unix(10, 0), orientation.Time)
+}
+
+func Test_QuaternionEuler(t *testing.T) {
+	roll, pitch, yaw := 10*degreesToRadians, -20*degreesToRadians, 30*degreesToRadians
+	q := QuaternionFromEuler(roll, pitch, yaw)
+
+	r, p, y := q.Euler()
+	assert.InDelta(t, roll, r, 1e-9)
+	assert.InDelta(t, pitch, p, 1e-9)
+	assert.InDelta(t, yaw, y, 1e-9)
+
+	forward := QuaternionFromEuler(0, 0, 90*degreesToRadians).Rotate([3]float64{1, 0, 0})
+	assert.InDelta(t, 0.0, forward[0], 1e-9)
+	assert.InDelta(t, 1.0, forward[1], 1e-9)
+}
@@ -0,0 +1,72 @@
+package fusion
+
+import "math"
+
+// Quaternion W + Xi + Yj + Zk. Orientations are unit quaternions rotating
+// the camera frame (X forward, Y left, Z up) into the earth frame (Z up).
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// QuaternionFromEuler builds the orientation from roll, pitch and yaw in
+// radians, applied in the yaw, pitch, roll (Z, Y, X) order.
+func QuaternionFromEuler(roll, pitch, yaw float64) Quaternion {
+	cr, sr := math.Cos(roll/2), math.Sin(roll/2)
+	cp, sp := math.Cos(pitch/2), math.Sin(pitch/2)
+	cy, sy := math.Cos(yaw/2), math.Sin(yaw/2)
+
+	return Quaternion{
+		W: cr*cp*cy + sr*sp*sy,
+		X: sr*cp*cy - cr*sp*sy,
+		Y: cr*sp*cy + sr*cp*sy,
+		Z: cr*cp*sy - sr*sp*cy,
+	}
+}
+
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+}
+
+func (q Quaternion) Normalize() Quaternion {
+	norm := q.Norm()
+	if norm == 0 {
+		return IdentityQuaternion()
+	}
+	return Quaternion{W: q.W / norm, X: q.X / norm, Y: q.Y / norm, Z: q.Z / norm}
+}
+
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+func (q Quaternion) Multiply(r Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+		X: q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		Y: q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		Z: q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+	}
+}
+
+// Rotate rotates v from the camera frame into the earth frame.
+func (q Quaternion) Rotate(v [3]float64) [3]float64 {
+	r := q.Multiply(Quaternion{X: v[0], Y: v[1], Z: v[2]}).Multiply(q.Conjugate())
+	return [3]float64{r.X, r.Y, r.Z}
+}
+
+// Euler returns roll, pitch and yaw in radians (Z, Y, X order). Angles follow
+// the right-hand rule around the camera axes: positive roll lowers the right
+// side, positive pitch lowers the nose and positive yaw turns left.
+func (q Quaternion) Euler() (roll, pitch, yaw float64) {
+	roll = math.Atan2(q.W*q.X+q.Y*q.Z, 0.5-q.X*q.X-q.Y*q.Y)
+	pitch = math.Asin(math.Max(-1, math.Min(1, 2*(q.W*q.Y-q.X*q.Z))))
+	yaw = math.Atan2(q.W*q.Z+q.X*q.Y, 0.5-q.Y*q.Y-q.Z*q.Z)
+	return roll, pitch, yaw
+}
@@ -0,0 +1,412 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+)
+
+var ErrStopped = errors.New("hub stopped")
+
+const (
+	minRetryDelay = 10 * time.Millisecond
+	maxRetryDelay = 5 * time.Second
+	// The sample rate is measured over windows of this duration.
+	rateWindow = time.Second
+)
+
+type Config struct {
+	// Time between two samples read from the device.
+	Period time.Duration
+	// Converts the samples to the camera frame for the event detection.
+	Transform *iim42652.MountTransform
+	Events    *events.Config
+	// Capacity of the channels of every subscription.
+	BufferSize int
+	// Called with the device errors, the stream is restarted after the read
+	// errors.
+	OnError func(err error)
+	// Puts the device in low power mode while the vehicle is parked and
+	// polls its motion interrupts meanwhile, see motion.PowerManager. The
+	// power mode is left alone when nil.
+	Power PowerDevice
+}
+
+// PowerDevice is implemented by *iim42652.IIM42652.
+type PowerDevice interface {
+	motion.PowerController
+	ReadMotionInterrupts() (iim42652.MotionInterrupts, error)
+}
+
+func DefaultConfig(transform *iim42652.MountTransform) *Config {
+	return &Config{
+		Period:     10 * time.Millisecond,
+		Transform:  transform,
+		Events:     events.DefaultConfig(),
+		BufferSize: 256,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.Period <= 0 {
+		return fmt.Errorf("period must be positive, got %s", c.Period)
+	}
+	if c.Transform == nil {
+		return fmt.Errorf("transform is required")
+	}
+	if c.Events == nil {
+		return fmt.Errorf("events config is required")
+	}
+	if c.BufferSize < 1 {
+		return fmt.Errorf("buffer size must be at least 1, got %d", c.BufferSize)
+	}
+	return nil
+}
+
+// Hub owns a device and fans its samples, and the events detected in them,
+// out to independent subscriptions. A subscriber too slow to keep up misses
+// samples and events, it never slows the others down.
+type Hub struct {
+	config    *Config
+	device    iim42652.Device
+	processor *events.Processor
+	power     *motion.PowerManager
+
+	lock          sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	stopped       bool
+	stats         Stats
+	latest        *iim42652.Sample
+	rateStart     time.Time
+	rateCount     int
+
+	pauses chan *pause
+	done   chan struct{}
+}
+
+type pause struct {
+	fn   func() error
+	done chan error
+}
+
+func New(device iim42652.Device, config *Config) (*Hub, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	processor, err := events.NewProcessor(config.Events)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	h := &Hub{
+		config:        config,
+		device:        device,
+		processor:     processor,
+		subscriptions: map[*Subscription]struct{}{},
+		pauses:        make(chan *pause),
+		done:          make(chan struct{}),
+	}
+	if config.Power != nil {
+		h.power = motion.NewPowerManager(config.Power)
+	}
+	return h, nil
+}
+
+func (h *Hub) Transform() *iim42652.MountTransform {
+	return h.config.Transform
+}
+
+func (h *Hub) Period() time.Duration {
+	return h.config.Period
+}
+
+// Run streams the device until ctx is done or the device reaches its end,
+// like a replay, and closes the subscriptions when it returns. It must only
+// be called once. Read errors
+// are reported to OnError and the stream is restarted after a delay doubling
+// up to 5s, reset once samples flow again.
+func (h *Hub) Run(ctx context.Context) error {
+	defer h.stop()
+
+	samples := make(chan *iim42652.Sample, h.config.BufferSize)
+	var streamErr chan error
+	var cancelStream context.CancelFunc
+	stream := func() {
+		var streamCtx context.Context
+		streamCtx, cancelStream = context.WithCancel(ctx)
+		errs := make(chan error, 1)
+		go func() {
+			errs <- h.device.Stream(streamCtx, h.config.Period, samples)
+		}()
+		streamErr = errs
+	}
+	stream()
+
+	delay := minRetryDelay
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			cancelStream()
+			return nil
+		case sample := <-samples:
+			delay = minRetryDelay
+			h.publish(sample)
+		case err := <-streamErr:
+			streamErr = nil
+			cancelStream()
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				h.drain(samples)
+				h.publishEvents(h.processor.Flush())
+				return nil
+			}
+			h.lock.Lock()
+			h.stats.ReadErrors++
+			h.lock.Unlock()
+			h.reportError(fmt.Errorf("reading samples, retrying in %s: %w", delay, err))
+			retry = time.After(delay)
+			delay *= 2
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+		case <-retry:
+			retry = nil
+			stream()
+		case p := <-h.pauses:
+			if streamErr != nil {
+				cancelStream()
+				<-streamErr
+				h.drain(samples)
+			}
+			p.done <- p.fn()
+			retry = nil
+			stream()
+		}
+	}
+}
+
+// Pause stops reading the device while fn runs, for operations like the
+// self-test that change its configuration. It waits for Run to be started
+// and fails with ErrStopped once Run returned.
+func (h *Hub) Pause(ctx context.Context, fn func() error) error {
+	p := &pause{fn: fn, done: make(chan error, 1)}
+	select {
+	case h.pauses <- p:
+	case <-h.done:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-p.done
+}
+
+func (h *Hub) drain(samples chan *iim42652.Sample) {
+	for {
+		select {
+		case sample := <-samples:
+			h.publish(sample)
+		default:
+			return
+		}
+	}
+}
+
+func (h *Hub) publish(sample *iim42652.Sample) {
+	detected := h.processor.Process(h.config.Transform.Sample(sample))
+	if h.power != nil && h.power.LowPower() {
+		detected = append(detected, h.pollMotionInterrupts(sample.Time)...)
+	}
+
+	h.lock.Lock()
+	h.record(sample)
+	for subscription := range h.subscriptions {
+		subscription.sendSample(sample)
+	}
+	h.lock.Unlock()
+
+	h.publishEvents(detected)
+	h.switchPower(detected)
+}
+
+// pollMotionInterrupts reads the significant motion interrupt, which is what
+// wakes the vehicle up while the gyroscope is off.
+func (h *Hub) pollMotionInterrupts(t time.Time) []*events.Event {
+	interrupts, err := h.config.Power.ReadMotionInterrupts()
+	if err != nil {
+		h.reportError(fmt.Errorf("reading motion interrupts: %w", err))
+		return nil
+	}
+	if !interrupts.SignificantMotion {
+		return nil
+	}
+	return h.processor.ProcessMotionInterrupt(t)
+}
+
+func (h *Hub) switchPower(detected []*events.Event) {
+	if h.power == nil {
+		return
+	}
+	for _, event := range detected {
+		if event.Type != events.TypeMotionState {
+			continue
+		}
+		if err := h.power.Handle(event.MotionState); err != nil {
+			h.reportError(err)
+		}
+	}
+}
+
+func (h *Hub) reportError(err error) {
+	if h.config.OnError != nil {
+		h.config.OnError(err)
+	}
+}
+
+// record updates the statistics, the hub lock must be held.
+func (h *Hub) record(sample *iim42652.Sample) {
+	h.stats.Samples++
+	h.stats.State = h.processor.State()
+	h.latest = sample
+
+	h.rateCount++
+	if h.rateStart.IsZero() {
+		h.rateStart, h.rateCount = sample.Time, 0
+	} else if elapsed := sample.Time.Sub(h.rateStart); elapsed >= rateWindow {
+		h.stats.SampleRate = float64(h.rateCount) / elapsed.Seconds()
+		h.rateStart, h.rateCount = sample.Time, 0
+	}
+}
+
+func (h *Hub) publishEvents(detected []*events.Event) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, event := range detected {
+		for subscription := range h.subscriptions {
+			subscription.sendEvent(event)
+		}
+	}
+}
+
+func (h *Hub) stop() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.stopped = true
+	close(h.done)
+	for subscription := range h.subscriptions {
+		subscription.close()
+		delete(h.subscriptions, subscription)
+	}
+}
+
+// Subscribe returns a subscription to the samples and events read from now
+// on. Its channels are closed by Unsubscribe, or once the hub stops.
+func (h *Hub) Subscribe() *Subscription {
+	subscription := &Subscription{
+		samples: make(chan *iim42652.Sample, h.config.BufferSize),
+		events:  make(chan *events.Event, h.config.BufferSize),
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.stopped {
+		subscription.close()
+	} else {
+		h.subscriptions[subscription] = struct{}{}
+	}
+	return subscription
+}
+
+// Stats are the hub statistics since Run was called.
+type Stats struct {
+	Samples    uint64 `json:"samples"`
+	ReadErrors uint64 `json:"read_errors"`
+	// Measured from the sample timestamps over the last second.
+	SampleRate float64      `json:"sample_rate_hz"`
+	State      motion.State `json:"motion_state"`
+}
+
+func (h *Hub) Stats() Stats {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.stats
+}
+
+// Latest returns the last sample read, nil before the first one.
+func (h *Hub) Latest() *iim42652.Sample {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.latest
+}
+
+func (h *Hub) Subscribers() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return len(h.subscriptions)
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, found := h.subscriptions[subscription]; found {
+		subscription.close()
+		delete(h.subscriptions, subscription)
+	}
+}
+
+type Subscription struct {
+	samples chan *iim42652.Sample
+	events  chan *events.Event
+
+	droppedSamples atomic.Uint64
+	droppedEvents  atomic.Uint64
+}
+
+func (s *Subscription) Samples() <-chan *iim42652.Sample {
+	return s.samples
+}
+
+func (s *Subscription) Events() <-chan *events.Event {
+	return s.events
+}
+
+// DroppedSamples returns the number of samples missed because the samples
+// channel was full.
+func (s *Subscription) DroppedSamples() uint64 {
+	return s.droppedSamples.Load()
+}
+
+func (s *Subscription) DroppedEvents() uint64 {
+	return s.droppedEvents.Load()
+}
+
+func (s *Subscription) sendSample(sample *iim42652.Sample) {
+	select {
+	case s.samples <- sample:
+	default:
+		s.droppedSamples.Add(1)
+	}
+}
+
+func (s *Subscription) sendEvent(event *events.Event) {
+	select {
+	case s.events <- event:
+	default:
+		s.droppedEvents.Add(1)
+	}
+}
+
+func (s *Subscription) close() {
+	close(s.samples)
+	close(s.events)
+}
@@ -0,0 +1,243 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice streams count samples, failing once after the 50th, then returns
+// io.EOF. Streaming only starts once start is closed.
+type fakeDevice struct {
+	iim42652.Device
+	start chan struct{}
+	count int
+
+	lock      sync.Mutex
+	next      int
+	failed    bool
+	streaming bool
+}
+
+func (d *fakeDevice) Stream(ctx context.Context, period time.Duration, samples chan<- *iim42652.Sample) error {
+	<-d.start
+	d.setStreaming(true)
+	defer d.setStreaming(false)
+
+	for {
+		d.lock.Lock()
+		n := d.next
+		fail := n == 50 && !d.failed
+		if fail {
+			d.failed = true
+		} else {
+			d.next++
+		}
+		d.lock.Unlock()
+
+		if fail {
+			return errors.New("spi transaction failed")
+		}
+		if n >= d.count {
+			return io.EOF
+		}
+
+		acceleration := iim42652.NewAcceleration(0, 0, 2048, iim42652.AccelerationSensitivityG16)
+		if n == 80 {
+			acceleration = iim42652.NewAcceleration(0, 0, 10240, iim42652.AccelerationSensitivityG16)
+		}
+		select {
+		case samples <- &iim42652.Sample{
+			Time:         time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond),
+			Acceleration: acceleration,
+			AngularRate:  iim42652.NewGyroscope(0, 0, 0, iim42652.GyroScalesG2000),
+		}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *fakeDevice) setStreaming(streaming bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.streaming = streaming
+}
+
+func newHub(t *testing.T, device iim42652.Device, onError func(err error)) *Hub {
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	config := DefaultConfig(transform)
+	config.BufferSize = 200
+	config.OnError = onError
+	h, err := New(device, config)
+	require.NoError(t, err)
+	return h
+}
+
+func Test_Hub(t *testing.T) {
+	device := &fakeDevice{start: make(chan struct{}), count: 150}
+	var reported []error
+	h := newHub(t, device, func(err error) { reported = append(reported, err) })
+
+	all := h.Subscribe()
+	slow := h.Subscribe()
+	// A consumer that never reads, with room for 10 samples.
+	slow.samples = make(chan *iim42652.Sample, 10)
+	unsubscribed := h.Subscribe()
+	h.Unsubscribe(unsubscribed)
+	_, open := <-unsubscribed.Samples()
+	assert.False(t, open)
+
+	close(device.start)
+	require.NoError(t, h.Run(context.Background()))
+
+	var samples []*iim42652.Sample
+	for sample := range all.Samples() {
+		samples = append(samples, sample)
+	}
+	require.Len(t, samples, 150)
+	for n, sample := range samples {
+		assert.Equal(t, time.Unix(0, 0).Add(time.Duration(n)*10*time.Millisecond), sample.Time)
+	}
+
+	var detected []*events.Event
+	for event := range all.Events() {
+		detected = append(detected, event)
+	}
+	require.NotEmpty(t, detected)
+	impact := detected[len(detected)-1]
+	assert.Equal(t, events.TypeImpact, impact.Type, "flushed once the device ends")
+	assert.Equal(t, time.Unix(0, 0).Add(800*time.Millisecond), impact.Time)
+
+	assert.Equal(t, uint64(0), all.DroppedSamples())
+	assert.Equal(t, uint64(140), slow.DroppedSamples())
+	assert.Len(t, reported, 1)
+	assert.Equal(t, Stats{Samples: 150, ReadErrors: 1, SampleRate: 100, State: motion.StateStationary}, h.Stats())
+	assert.Equal(t, samples[149], h.Latest())
+
+	_, open = <-h.Subscribe().Samples()
+	assert.False(t, open, "subscriptions after the hub stopped are closed")
+}
+
+func Test_Pause(t *testing.T) {
+	device := &fakeDevice{start: make(chan struct{}), count: 1 << 30}
+	h := newHub(t, device, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- h.Run(ctx) }()
+	close(device.start)
+
+	subscription := h.Subscribe()
+	<-subscription.Samples()
+
+	err := h.Pause(ctx, func() error {
+		device.lock.Lock()
+		defer device.lock.Unlock()
+		assert.False(t, device.streaming)
+		return errors.New("self-test failed")
+	})
+	assert.EqualError(t, err, "self-test failed")
+
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.ErrorIs(t, h.Pause(context.Background(), func() error { return nil }), ErrStopped)
+}
+
+// fakePowerDevice streams count stationary samples. Its gyroscope reads
+// invalid data in low power mode, and the significant motion interrupt is
+// raised at the wakeUp-th poll.
+type fakePowerDevice struct {
+	iim42652.Device
+	count  int
+	wakeUp int
+
+	lock     sync.Mutex
+	lowPower bool
+	invalid  int
+	polls    int
+	calls    []string
+}
+
+func (d *fakePowerDevice) Stream(ctx context.Context, period time.Duration, samples chan<- *iim42652.Sample) error {
+	for n := 0; n < d.count; n++ {
+		angularRate := iim42652.NewGyroscope(0, 0, 0, iim42652.GyroScalesG2000)
+		d.lock.Lock()
+		if d.lowPower {
+			angularRate = iim42652.NewGyroscope(iim42652.InvalidData, iim42652.InvalidData, iim42652.InvalidData, iim42652.GyroScalesG2000)
+			d.invalid++
+		}
+		d.lock.Unlock()
+
+		select {
+		case samples <- &iim42652.Sample{
+			Time:         time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond),
+			Acceleration: iim42652.NewAcceleration(0, 0, 2048, iim42652.AccelerationSensitivityG16),
+			AngularRate:  angularRate,
+		}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return io.EOF
+}
+
+func (d *fakePowerDevice) EnterLowPowerMode() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.lowPower = true
+	d.calls = append(d.calls, "enter")
+	return nil
+}
+
+func (d *fakePowerDevice) ExitLowPowerMode() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.lowPower = false
+	d.calls = append(d.calls, "exit")
+	return nil
+}
+
+func (d *fakePowerDevice) ReadMotionInterrupts() (iim42652.MotionInterrupts, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.polls++
+	return iim42652.MotionInterrupts{SignificantMotion: d.polls == d.wakeUp}, nil
+}
+
+func Test_LowPower(t *testing.T) {
+	device := &fakePowerDevice{count: 1000, wakeUp: 600}
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	config := DefaultConfig(transform)
+	config.BufferSize = 200
+	config.Events.Motion.ParkedDwell = 2 * time.Second
+	config.Power = device
+	config.OnError = func(err error) { t.Error(err) }
+	h, err := New(device, config)
+	require.NoError(t, err)
+
+	subscription := h.Subscribe()
+	require.NoError(t, h.Run(context.Background()))
+
+	var states []motion.State
+	for event := range subscription.Events() {
+		if event.Type == events.TypeMotionState {
+			states = append(states, event.MotionState.To)
+		}
+	}
+	assert.Equal(t, []motion.State{motion.StateStationary, motion.StateParked, motion.StateMoving}, states, "parked until the motion interrupt")
+	assert.Equal(t, []string{"enter", "exit"}, device.calls)
+	assert.Greater(t, device.invalid, 0, "samples were read with the gyroscope off")
+}
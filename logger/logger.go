@@ -0,0 +1,394 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+)
+
+const (
+	segmentExtension    = ".imurec"
+	compressedExtension = ".gz"
+	segmentTimeLayout   = "20060102T150405.000Z"
+)
+
+// ErrClosed is returned by Write and Close once the logger is closed.
+var ErrClosed = errors.New("logger closed")
+
+type Config struct {
+	Directory string
+	// Segment file names are <Prefix>-<UTC start time>.imurec.
+	Prefix string
+	// A segment is closed once larger than this, in bytes. 0 disables.
+	MaxSegmentSize int64
+	// A segment is closed once open for this long. 0 disables.
+	MaxSegmentDuration time.Duration
+	// Oldest closed segments are deleted while all segments take more than
+	// this, in bytes. 0 disables.
+	Quota int64
+	// The current segment is flushed and synced to disk this often, bounding
+	// what a power cut loses. Run syncs on a timer as well, for the samples
+	// buffered when the device stops delivering them.
+	SyncInterval time.Duration
+	// Closed segments are gzipped.
+	Compress bool
+	// Called with the errors of the background compression and cleanup,
+	// they do not stop logging.
+	OnError func(err error)
+}
+
+func DefaultConfig(directory string) *Config {
+	return &Config{
+		Directory:          directory,
+		Prefix:             "imu",
+		MaxSegmentSize:     16 << 20,
+		MaxSegmentDuration: 10 * time.Minute,
+		Quota:              1 << 30,
+		SyncInterval:       2 * time.Second,
+		Compress:           true,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.Directory == "" {
+		return fmt.Errorf("directory is required")
+	}
+	if c.Prefix == "" || strings.ContainsRune(c.Prefix, filepath.Separator) {
+		return fmt.Errorf("invalid prefix %q", c.Prefix)
+	}
+	if c.MaxSegmentSize < 0 || c.MaxSegmentDuration < 0 || c.Quota < 0 {
+		return fmt.Errorf("segment limits and quota must not be negative")
+	}
+	if c.SyncInterval <= 0 {
+		return fmt.Errorf("sync interval must be positive, got %s", c.SyncInterval)
+	}
+	return nil
+}
+
+// Logger writes samples to recording segments rotated by size or duration.
+// Closed segments are compressed and the oldest deleted past the quota in
+// the background. It is not safe for concurrent use.
+type Logger struct {
+	config *Config
+	header *recording.Header
+	// Set by Close.
+	stopped bool
+
+	file         *os.File
+	counter      *countingWriter
+	writer       *recording.Writer
+	segmentStart time.Time
+	lastSync     time.Time
+
+	// Path of the segment being written, never deleted by the quota.
+	current     string
+	currentLock sync.Mutex
+
+	closed chan string
+	done   chan struct{}
+
+	now func() time.Time
+}
+
+// New creates the directory when needed and queues the segments left
+// uncompressed by a previous run for compression. Every segment starts with
+// header, created at the segment start.
+func New(config *Config, header *recording.Header) (*Logger, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := os.MkdirAll(config.Directory, 0o755); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	l := &Logger{
+		config: config,
+		header: header,
+		closed: make(chan string, 16),
+		done:   make(chan struct{}),
+		now:    time.Now,
+	}
+
+	leftovers, err := l.segments()
+	if err != nil {
+		return nil, err
+	}
+	// Compressions interrupted by a power cut, their segment is still there.
+	temporaries, err := filepath.Glob(filepath.Join(config.Directory, config.Prefix+"-*"+segmentExtension+compressedExtension+".tmp"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range temporaries {
+		os.Remove(path)
+	}
+	go l.background(leftovers)
+	return l, nil
+}
+
+func (l *Logger) Write(sample *iim42652.Sample) error {
+	if l.stopped {
+		return ErrClosed
+	}
+	now := l.now()
+	if l.writer != nil && l.config.MaxSegmentDuration > 0 && now.Sub(l.segmentStart) >= l.config.MaxSegmentDuration {
+		if err := l.closeSegment(); err != nil {
+			return err
+		}
+	}
+	if l.writer == nil {
+		if err := l.open(now); err != nil {
+			return err
+		}
+	}
+
+	if err := l.writer.WriteSample(sample); err != nil {
+		return fmt.Errorf("writing sample: %w", err)
+	}
+
+	if err := l.syncIfDue(now); err != nil {
+		return err
+	}
+
+	if l.config.MaxSegmentSize > 0 && l.size() >= l.config.MaxSegmentSize {
+		return l.closeSegment()
+	}
+	return nil
+}
+
+// Close closes the current segment and waits for the background work.
+func (l *Logger) Close() error {
+	if l.stopped {
+		return ErrClosed
+	}
+	l.stopped = true
+	err := l.closeSegment()
+	close(l.closed)
+	<-l.done
+	return err
+}
+
+func (l *Logger) open(now time.Time) error {
+	path := filepath.Join(l.config.Directory, l.config.Prefix+"-"+now.UTC().Format(segmentTimeLayout)+segmentExtension)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("creating segment: %w", err)
+	}
+
+	header := *l.header
+	header.Created = now
+	counter := &countingWriter{writer: file}
+	writer, err := recording.NewWriter(counter, &header)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("writing segment header: %w", err)
+	}
+
+	l.currentLock.Lock()
+	l.current = path
+	l.currentLock.Unlock()
+
+	l.file, l.counter, l.writer = file, counter, writer
+	l.segmentStart, l.lastSync = now, now
+	return nil
+}
+
+// size includes the records still buffered by the writer.
+func (l *Logger) size() int64 {
+	return l.counter.written + int64(l.writer.Buffered())
+}
+
+// syncIfDue syncs the current segment when it was not for SyncInterval.
+func (l *Logger) syncIfDue(now time.Time) error {
+	if l.writer == nil || now.Sub(l.lastSync) < l.config.SyncInterval {
+		return nil
+	}
+	return l.sync(now)
+}
+
+func (l *Logger) sync(now time.Time) error {
+	if err := l.writer.Flush(); err != nil {
+		return fmt.Errorf("flushing segment: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("syncing segment: %w", err)
+	}
+	l.lastSync = now
+	return nil
+}
+
+func (l *Logger) closeSegment() error {
+	if l.writer == nil {
+		return nil
+	}
+
+	path := l.file.Name()
+	err := l.sync(l.now())
+	if closeErr := l.file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("closing segment: %w", closeErr)
+	}
+	l.file, l.counter, l.writer = nil, nil, nil
+
+	l.currentLock.Lock()
+	l.current = ""
+	l.currentLock.Unlock()
+
+	l.closed <- path
+	return err
+}
+
+func (l *Logger) background(leftovers []string) {
+	defer close(l.done)
+
+	for _, path := range leftovers {
+		if strings.HasSuffix(path, segmentExtension) {
+			l.compress(path)
+		}
+	}
+	l.enforceQuota()
+
+	for path := range l.closed {
+		l.compress(path)
+		l.enforceQuota()
+	}
+}
+
+func (l *Logger) compress(path string) {
+	if !l.config.Compress {
+		return
+	}
+	if err := compressFile(path); err != nil {
+		l.reportError(fmt.Errorf("compressing %q: %w", path, err))
+	}
+}
+
+// compressFile gzips path next to it and removes it. The compressed file is
+// synced and renamed in place first so that a power cut never leaves both
+// missing.
+func compressFile(path string) error {
+	input, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	temporary := path + compressedExtension + ".tmp"
+	output, err := os.Create(temporary)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporary)
+
+	gz := gzip.NewWriter(output)
+	if _, err := io.Copy(gz, input); err != nil {
+		output.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		output.Close()
+		return err
+	}
+	if err := output.Sync(); err != nil {
+		output.Close()
+		return err
+	}
+	if err := output.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(temporary, path+compressedExtension); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// enforceQuota deletes the oldest segments while all of them, the current
+// one included, take more than the quota.
+func (l *Logger) enforceQuota() {
+	if l.config.Quota == 0 {
+		return
+	}
+
+	segments, err := l.segments()
+	if err != nil {
+		l.reportError(err)
+		return
+	}
+
+	sizes := make([]int64, len(segments))
+	total := int64(0)
+	for i, path := range segments {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		sizes[i] = info.Size()
+		total += sizes[i]
+	}
+
+	l.currentLock.Lock()
+	current := l.current
+	l.currentLock.Unlock()
+
+	for i, path := range segments {
+		if total <= l.config.Quota {
+			return
+		}
+		if path == current {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			l.reportError(fmt.Errorf("deleting %q: %w", path, err))
+			continue
+		}
+		total -= sizes[i]
+	}
+}
+
+// segments returns the paths of the segments in the directory, oldest first.
+func (l *Logger) segments() ([]string, error) {
+	entries, err := os.ReadDir(l.config.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("listing segments: %w", err)
+	}
+
+	var segments []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, l.config.Prefix+"-") {
+			continue
+		}
+		if strings.HasSuffix(name, segmentExtension) || strings.HasSuffix(name, segmentExtension+compressedExtension) {
+			segments = append(segments, filepath.Join(l.config.Directory, name))
+		}
+	}
+	// Names hold the start time, sorting them sorts the segments by age.
+	sort.Strings(segments)
+	return segments, nil
+}
+
+func (l *Logger) reportError(err error) {
+	if l.config.OnError != nil {
+		l.config.OnError(err)
+	}
+}
+
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	c.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,293 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var header = &recording.Header{
+	Configuration: &iim42652.Configuration{
+		AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+		GyroScale:               iim42652.GyroScalesG2000,
+	},
+}
+
+func sample(n int) *iim42652.Sample {
+	return &iim42652.Sample{
+		Time:         time.Unix(0, 0).Add(time.Duration(n) * 10 * time.Millisecond),
+		Acceleration: iim42652.NewAcceleration(int16(n), 0, 0, iim42652.AccelerationSensitivityG16),
+		AngularRate:  iim42652.NewGyroscope(0, int16(n), 0, iim42652.GyroScalesG2000),
+	}
+}
+
+// newLogger returns a logger whose clock is at the time of the last sample
+// written with write.
+func newLogger(t *testing.T, update func(c *Config)) (*Logger, *Config) {
+	config := DefaultConfig(t.TempDir())
+	update(config)
+	l, err := New(config, header)
+	require.NoError(t, err)
+
+	l.now = func() time.Time { return clock }
+	return l, config
+}
+
+var clock time.Time
+
+func write(t *testing.T, l *Logger, n int) {
+	clock = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(n) * 10 * time.Millisecond)
+	require.NoError(t, l.Write(sample(n)))
+}
+
+// readSegments returns the raw X acceleration of every record, segment by
+// segment.
+func readSegments(t *testing.T, directory string) (names []string, values [][]int16) {
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+
+		file, err := os.Open(filepath.Join(directory, entry.Name()))
+		require.NoError(t, err)
+		defer file.Close()
+
+		var input io.Reader = file
+		if strings.HasSuffix(entry.Name(), ".gz") {
+			input, err = gzip.NewReader(file)
+			require.NoError(t, err)
+		}
+		reader, err := recording.NewReader(input)
+		require.NoError(t, err)
+		records, err := reader.ReadAll()
+		require.NoError(t, err)
+
+		var segment []int16
+		for _, record := range records {
+			segment = append(segment, record.Acceleration[0])
+		}
+		values = append(values, segment)
+	}
+	return names, values
+}
+
+func Test_Rotation(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           func(c *Config)
+		expectedSegments []int
+	}{
+		{
+			name: "by size",
+			config: func(c *Config) {
+				c.MaxSegmentSize = 1000
+				c.MaxSegmentDuration = 0
+			},
+			// The header fills most of a segment, records are 22 bytes.
+			expectedSegments: []int{10, 10, 10, 10, 10, 10, 10, 10, 10, 10},
+		},
+		{
+			name: "by duration",
+			config: func(c *Config) {
+				c.MaxSegmentSize = 0
+				c.MaxSegmentDuration = 200 * time.Millisecond
+			},
+			expectedSegments: []int{20, 20, 20, 20, 20},
+		},
+		{
+			name: "uncompressed",
+			config: func(c *Config) {
+				c.MaxSegmentSize = 0
+				c.MaxSegmentDuration = 500 * time.Millisecond
+				c.Compress = false
+			},
+			expectedSegments: []int{50, 50},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, config := newLogger(t, test.config)
+			for n := 0; n < 100; n++ {
+				write(t, l, n)
+			}
+			require.NoError(t, l.Close())
+
+			names, values := readSegments(t, config.Directory)
+			var all []int16
+			for i, name := range names {
+				assert.True(t, strings.HasPrefix(name, "imu-20230601T120000."), name)
+				assert.Equal(t, config.Compress, strings.HasSuffix(name, ".imurec.gz"), name)
+				all = append(all, values[i]...)
+			}
+			var lengths []int
+			for _, segment := range values {
+				lengths = append(lengths, len(segment))
+			}
+			assert.Equal(t, test.expectedSegments, lengths)
+			require.Len(t, all, 100)
+			for n, value := range all {
+				assert.Equal(t, int16(n), value)
+			}
+		})
+	}
+}
+
+func Test_Quota(t *testing.T) {
+	l, config := newLogger(t, func(c *Config) {
+		c.MaxSegmentSize = 0
+		c.MaxSegmentDuration = 100 * time.Millisecond
+		c.Compress = false
+		c.Quota = 4000
+	})
+	for n := 0; n < 200; n++ {
+		write(t, l, n)
+	}
+	require.NoError(t, l.Close())
+
+	names, values := readSegments(t, config.Directory)
+	total := int64(0)
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(config.Directory, name))
+		require.NoError(t, err)
+		total += info.Size()
+	}
+	assert.LessOrEqual(t, total, int64(4000))
+	assert.Less(t, len(names), 20)
+	assert.Equal(t, int16(199), values[len(values)-1][9], "newest segments are kept")
+}
+
+func Test_Sync(t *testing.T) {
+	l, config := newLogger(t, func(c *Config) { c.SyncInterval = 100 * time.Millisecond })
+	for n := 0; n < 25; n++ {
+		write(t, l, n)
+	}
+
+	// Synced at the 11th and 21st samples, the last 4 are still buffered.
+	_, values := readSegments(t, config.Directory)
+	require.Len(t, values, 1)
+	assert.Len(t, values[0], 21)
+	require.NoError(t, l.Close())
+}
+
+func Test_SyncWithoutSamples(t *testing.T) {
+	l, config := newLogger(t, func(c *Config) { c.SyncInterval = 100 * time.Millisecond })
+	for n := 0; n < 5; n++ {
+		write(t, l, n)
+	}
+	size := func() int64 {
+		info, err := os.Stat(l.file.Name())
+		require.NoError(t, err)
+		return info.Size()
+	}
+	assert.Zero(t, size())
+
+	// Run syncs on its timer when the samples stop coming.
+	clock = clock.Add(50 * time.Millisecond)
+	require.NoError(t, l.syncIfDue(clock))
+	assert.Zero(t, size())
+	clock = clock.Add(100 * time.Millisecond)
+	require.NoError(t, l.syncIfDue(clock))
+	_, values := readSegments(t, config.Directory)
+	assert.Len(t, values[0], 5)
+	require.NoError(t, l.Close())
+}
+
+func Test_WriteAfterClose(t *testing.T) {
+	l, config := newLogger(t, func(c *Config) { c.Compress = false })
+	write(t, l, 0)
+	require.NoError(t, l.Close())
+
+	assert.ErrorIs(t, l.Write(sample(1)), ErrClosed)
+	assert.ErrorIs(t, l.Close(), ErrClosed)
+	names, _ := readSegments(t, config.Directory)
+	assert.Len(t, names, 1)
+}
+
+func Test_Recovery(t *testing.T) {
+	directory := t.TempDir()
+
+	// A segment left by a power cut, with a truncated last record, and its
+	// interrupted compression.
+	file, err := os.Create(filepath.Join(directory, "imu-20230601T110000.000Z.imurec"))
+	require.NoError(t, err)
+	writer, err := recording.NewWriter(file, header)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteSample(sample(1)))
+	require.NoError(t, writer.Flush())
+	_, err = file.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "imu-20230601T110000.000Z.imurec.gz.tmp"), []byte("partial"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "other.txt"), []byte("kept"), 0o644))
+
+	config := DefaultConfig(directory)
+	l, err := New(config, header)
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"imu-20230601T110000.000Z.imurec.gz", "other.txt"}, names)
+}
+
+type flakyDevice struct {
+	iim42652.Device
+	lock     sync.Mutex
+	next     int
+	failures int
+	cancel   func()
+}
+
+// Stream fails every 30 samples, and cancels the run after 100.
+func (d *flakyDevice) Stream(ctx context.Context, period time.Duration, samples chan<- *iim42652.Sample) error {
+	for {
+		d.lock.Lock()
+		n := d.next
+		d.next++
+		d.lock.Unlock()
+
+		if n == 100 {
+			d.cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		if n%30 == 29 {
+			d.failures++
+			return errors.New("spi transaction failed")
+		}
+		samples <- sample(n)
+	}
+}
+
+func Test_Run(t *testing.T) {
+	l, config := newLogger(t, func(c *Config) { c.Compress = false })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	device := &flakyDevice{cancel: cancel}
+	var reported []error
+	require.NoError(t, Run(ctx, device, time.Millisecond, l, func(err error) { reported = append(reported, err) }))
+	require.NoError(t, l.Close())
+
+	assert.Len(t, reported, 3)
+	_, values := readSegments(t, config.Directory)
+	require.Len(t, values, 1)
+	assert.Len(t, values[0], 97)
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+const (
+	minRetryDelay = 10 * time.Millisecond
+	maxRetryDelay = 5 * time.Second
+)
+
+// Run streams samples from device to the logger every period until ctx is
+// done. Read errors, like transient SPI failures, are reported to onError and
+// the stream is restarted after a delay doubling up to 5s, reset once samples
+// flow again. Only write errors stop Run before ctx is done. The current
+// segment is synced every SyncInterval even when no sample comes.
+func Run(ctx context.Context, device iim42652.Device, period time.Duration, l *Logger, onError func(err error)) error {
+	samples := make(chan *iim42652.Sample, 1024)
+	streamErr := make(chan error, 1)
+	stream := func() {
+		go func() {
+			streamErr <- device.Stream(ctx, period, samples)
+		}()
+	}
+	stream()
+
+	ticker := time.NewTicker(l.config.SyncInterval)
+	defer ticker.Stop()
+
+	delay := minRetryDelay
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return drain(samples, l)
+		case sample := <-samples:
+			delay = minRetryDelay
+			if err := l.Write(sample); err != nil {
+				return err
+			}
+		case err := <-streamErr:
+			if ctx.Err() != nil {
+				return drain(samples, l)
+			}
+			if onError != nil {
+				onError(fmt.Errorf("reading samples, retrying in %s: %w", delay, err))
+			}
+			retry = time.After(delay)
+			delay *= 2
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+		case <-retry:
+			retry = nil
+			stream()
+		case <-ticker.C:
+			if err := l.syncIfDue(l.now()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// drain writes the samples read before ctx was done.
+func drain(samples chan *iim42652.Sample, l *Logger) error {
+	for {
+		select {
+		case sample := <-samples:
+			if err := l.Write(sample); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
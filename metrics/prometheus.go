@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+const rateWindow = time.Second
+
+// Prometheus implements iim42652.Metrics with the following metrics:
+//
+//	imu_spi_transactions_total{register, operation}  SPI transactions
+//	imu_spi_failures_total{register, operation}      failed SPI transactions
+//	imu_sample_read_duration_seconds                 time to read a sample
+//	imu_sample_read_failures_total                   failed sample reads
+//	imu_samples_total                                samples streamed
+//	imu_samples_dropped_total                        samples dropped, the consumer was too slow
+//	imu_sample_periods_missed_total                  sample periods skipped, a read was too slow
+//	imu_sample_rate_hz                               sample rate over the last second
+//	imu_temperature_celsius                          last temperature read
+//
+// The register label is the bank and the address, like "00:1f", the
+// operation label is "read" or "write".
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	transactions *prometheus.CounterVec
+	failures     *prometheus.CounterVec
+	readDuration prometheus.Histogram
+	readFailures prometheus.Counter
+	samples      prometheus.Counter
+	dropped      prometheus.Counter
+	missed       prometheus.Counter
+	sampleRate   prometheus.Gauge
+	temperature  prometheus.Gauge
+
+	lock      sync.Mutex
+	rateStart time.Time
+	rateCount int
+}
+
+var _ iim42652.Metrics = (*Prometheus)(nil)
+
+// NewPrometheus registers the metrics to registry.
+func NewPrometheus(registry *prometheus.Registry) (*Prometheus, error) {
+	labels := []string{"register", "operation"}
+	p := &Prometheus{
+		registry: registry,
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "imu_spi_transactions_total",
+			Help: "SPI transactions by register.",
+		}, labels),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "imu_spi_failures_total",
+			Help: "Failed SPI transactions by register.",
+		}, labels),
+		readDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "imu_sample_read_duration_seconds",
+			Help:    "Time to read the acceleration, angular rate and temperature of a sample.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 10),
+		}),
+		readFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "imu_sample_read_failures_total",
+			Help: "Failed sample reads.",
+		}),
+		samples: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "imu_samples_total",
+			Help: "Samples streamed, dropped included.",
+		}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "imu_samples_dropped_total",
+			Help: "Samples dropped because the consumer was too slow.",
+		}),
+		missed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "imu_sample_periods_missed_total",
+			Help: "Sample periods skipped because a read took longer than the period.",
+		}),
+		sampleRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "imu_sample_rate_hz",
+			Help: "Effective sample rate over the last second, measured from the sample timestamps.",
+		}),
+		temperature: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "imu_temperature_celsius",
+			Help: "Last temperature read from the sensor.",
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		p.transactions, p.failures, p.readDuration, p.readFailures, p.samples, p.dropped, p.missed,
+		p.sampleRate, p.temperature,
+	} {
+		if err := registry.Register(collector); err != nil {
+			return nil, fmt.Errorf("registering metrics: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func (p *Prometheus) SPITransaction(reg *iim42652.Register, write bool, err error) {
+	operation := "read"
+	if write {
+		operation = "write"
+	}
+	register := fmt.Sprintf("%s:%s", reg.Bank, reg.Address)
+	p.transactions.WithLabelValues(register, operation).Inc()
+	if err != nil {
+		p.failures.WithLabelValues(register, operation).Inc()
+	}
+}
+
+func (p *Prometheus) SampleRead(latency time.Duration, err error) {
+	p.readDuration.Observe(latency.Seconds())
+	if err != nil {
+		p.readFailures.Inc()
+	}
+}
+
+func (p *Prometheus) SampleStreamed(sample *iim42652.Sample, dropped bool) {
+	p.samples.Inc()
+	if dropped {
+		p.dropped.Inc()
+	}
+	p.temperature.Set(sample.Temperature)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.rateCount++
+	if p.rateStart.IsZero() {
+		p.rateStart, p.rateCount = sample.Time, 0
+	} else if elapsed := sample.Time.Sub(p.rateStart); elapsed >= rateWindow {
+		p.sampleRate.Set(float64(p.rateCount) / elapsed.Seconds())
+		p.rateStart, p.rateCount = sample.Time, 0
+	}
+}
+
+func (p *Prometheus) PeriodsMissed(missed int) {
+	p.missed.Add(float64(missed))
+}
+
+// Handler serves the metrics of the registry in the Prometheus text format.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe serves Handler on /metrics until ctx is done.
+func (p *Prometheus) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %q: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p.Handler())
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
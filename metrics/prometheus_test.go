@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Prometheus(t *testing.T) {
+	p, err := NewPrometheus(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	p.SPITransaction(iim42652.RegisterAccelDataX1, false, nil)
+	p.SPITransaction(iim42652.RegisterAccelDataX1, false, errors.New("bus error"))
+	p.SPITransaction(iim42652.RegisterAccelWomXThreshold, true, nil)
+	assert.Equal(t, 2.0, testutil.ToFloat64(p.transactions.WithLabelValues("00:1f", "read")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(p.failures.WithLabelValues("00:1f", "read")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(p.transactions.WithLabelValues("04:4a", "write")))
+
+	p.SampleRead(2*time.Millisecond, nil)
+	p.SampleRead(3*time.Millisecond, errors.New("bus error"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(p.readFailures))
+	assert.Equal(t, 1, testutil.CollectAndCount(p.readDuration))
+
+	start := time.Unix(0, 0)
+	for n := 0; n <= 150; n++ {
+		p.SampleStreamed(&iim42652.Sample{
+			Time:        start.Add(time.Duration(n) * 10 * time.Millisecond),
+			Temperature: 25 + float64(n)/100,
+		}, n%50 == 0)
+	}
+	p.PeriodsMissed(3)
+	assert.Equal(t, 151.0, testutil.ToFloat64(p.samples))
+	assert.Equal(t, 4.0, testutil.ToFloat64(p.dropped))
+	assert.Equal(t, 3.0, testutil.ToFloat64(p.missed))
+	assert.Equal(t, 100.0, testutil.ToFloat64(p.sampleRate))
+	assert.Equal(t, 26.5, testutil.ToFloat64(p.temperature))
+
+	response := httptest.NewRecorder()
+	p.Handler().ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `imu_spi_failures_total{operation="read",register="00:1f"} 1`)
+	assert.Contains(t, string(body), "imu_samples_dropped_total 4")
+}
@@ -0,0 +1,228 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/events"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Config struct {
+	// Broker URL, like tcp://localhost:1883.
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+	QoS      byte
+
+	// Messages are not published to empty topics. The events are imu.v1.Event
+	// messages and the summaries imu.v1.Summary ones, see proto/imu/v1. The
+	// motion state is retained, a new subscriber receives the last one.
+	DrivingTopic     string
+	ImpactTopic      string
+	MotionStateTopic string
+	SummaryTopic     string
+
+	SummaryInterval time.Duration
+	// A publication not acknowledged within this time is reported as failed.
+	PublishTimeout       time.Duration
+	MaxReconnectInterval time.Duration
+	OnError              func(err error)
+}
+
+func DefaultConfig(broker string) *Config {
+	return &Config{
+		Broker:               broker,
+		ClientID:             "imud",
+		QoS:                  1,
+		DrivingTopic:         "imu/driving",
+		ImpactTopic:          "imu/impact",
+		MotionStateTopic:     "imu/motion_state",
+		SummaryTopic:         "imu/summary",
+		SummaryInterval:      time.Minute,
+		PublishTimeout:       10 * time.Second,
+		MaxReconnectInterval: time.Minute,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.Broker == "" {
+		return fmt.Errorf("broker is required")
+	}
+	if c.QoS > 2 {
+		return fmt.Errorf("qos must be 0, 1 or 2, got %d", c.QoS)
+	}
+	if c.SummaryTopic != "" && c.SummaryInterval <= 0 {
+		return fmt.Errorf("summary interval must be positive, got %s", c.SummaryInterval)
+	}
+	if c.PublishTimeout <= 0 {
+		return fmt.Errorf("publish timeout must be positive, got %s", c.PublishTimeout)
+	}
+	if c.MaxReconnectInterval <= 0 {
+		return fmt.Errorf("max reconnect interval must be positive, got %s", c.MaxReconnectInterval)
+	}
+	return nil
+}
+
+// Client is the part of paho.Client used by the Publisher.
+type Client interface {
+	Connect() paho.Token
+	Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token
+	Disconnect(quiesce uint)
+}
+
+// Publisher publishes the events detected by a hub and periodic summaries of
+// its activity to an MQTT broker, as JSON encoded with imuv1.JSON. The connection is retried until it
+// succeeds and restored when lost, messages published meanwhile are queued.
+type Publisher struct {
+	config *Config
+	hub    *hub.Hub
+	client Client
+
+	// Only used by the Run goroutine.
+	summary *imuv1.Summary
+	last    hub.Stats
+}
+
+func NewPublisher(h *hub.Hub, config *Config) (*Publisher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	p := &Publisher{config: config, hub: h}
+	options := paho.NewClientOptions().
+		AddBroker(config.Broker).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetMaxReconnectInterval(config.MaxReconnectInterval).
+		SetOnConnectHandler(func(paho.Client) { p.onConnect() }).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			p.onError(fmt.Errorf("connection to %q lost: %w", config.Broker, err))
+		})
+	p.client = paho.NewClient(options)
+	return p, nil
+}
+
+// Run publishes the events of the hub until ctx is done or the hub stops,
+// then publishes a last summary and disconnects.
+func (p *Publisher) Run(ctx context.Context) error {
+	subscription := p.hub.Subscribe()
+	defer p.hub.Unsubscribe(subscription)
+
+	p.summary, p.last = newSummary(time.Now()), p.hub.Stats()
+	connected := p.client.Connect()
+	go func() {
+		if connected.Wait(); connected.Error() != nil {
+			p.onError(fmt.Errorf("connecting to %q: %w", p.config.Broker, connected.Error()))
+		}
+	}()
+	defer p.client.Disconnect(250)
+
+	var tick <-chan time.Time
+	if p.config.SummaryTopic != "" {
+		ticker := time.NewTicker(p.config.SummaryInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.publishSummary(time.Now())
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				p.publishSummary(time.Now())
+				return nil
+			}
+			p.publishEvent(event)
+		case now := <-tick:
+			p.publishSummary(now)
+		}
+	}
+}
+
+func (p *Publisher) publishEvent(event *events.Event) {
+	switch event.Type {
+	case events.TypeMotionState:
+		p.publish(p.config.MotionStateTopic, true, imuv1.NewEvent(event))
+	case events.TypeDriving:
+		p.summary.DrivingEvents[imuv1.NewDrivingEventType(event.Driving.Type).String()]++
+		p.publish(p.config.DrivingTopic, false, imuv1.NewEvent(event))
+	case events.TypeImpact:
+		p.summary.Impacts++
+		if magnitude := event.Impact.PeakMagnitude(); magnitude > p.summary.PeakImpact {
+			p.summary.PeakImpact = magnitude
+		}
+		p.publish(p.config.ImpactTopic, false, imuv1.NewEvent(event))
+	}
+}
+
+// onConnect publishes the current motion state, it may have changed while
+// the connection was lost. The previous state is unknown.
+func (p *Publisher) onConnect() {
+	state := p.hub.Stats().State
+	if state == motion.StateUnknown {
+		return
+	}
+	now := time.Now()
+	p.publish(p.config.MotionStateTopic, true, imuv1.NewEvent(&events.Event{
+		Type:        events.TypeMotionState,
+		Time:        now,
+		MotionState: &motion.StateChange{Time: now, To: state},
+	}))
+}
+
+func newSummary(start time.Time) *imuv1.Summary {
+	return &imuv1.Summary{Start: timestamppb.New(start), DrivingEvents: map[string]uint32{}}
+}
+
+func (p *Publisher) publishSummary(now time.Time) {
+	stats := p.hub.Stats()
+	summary := p.summary
+	summary.End = timestamppb.New(now)
+	summary.Samples = stats.Samples - p.last.Samples
+	summary.ReadErrors = stats.ReadErrors - p.last.ReadErrors
+	summary.SampleRateHz = stats.SampleRate
+	summary.MotionState = imuv1.NewMotionState(stats.State)
+	p.summary, p.last = newSummary(now), stats
+
+	p.publish(p.config.SummaryTopic, false, summary)
+}
+
+// publish does not wait for the broker, the outcome is reported to OnError.
+func (p *Publisher) publish(topic string, retained bool, message proto.Message) {
+	if topic == "" {
+		return
+	}
+	payload, err := imuv1.JSON.Marshal(message)
+	if err != nil {
+		p.onError(fmt.Errorf("encoding message for %q: %w", topic, err))
+		return
+	}
+
+	token := p.client.Publish(topic, p.config.QoS, retained, payload)
+	go func() {
+		if !token.WaitTimeout(p.config.PublishTimeout) {
+			p.onError(fmt.Errorf("publishing to %q: not acknowledged within %s", topic, p.config.PublishTimeout))
+		} else if err := token.Error(); err != nil {
+			p.onError(fmt.Errorf("publishing to %q: %w", topic, err))
+		}
+	}()
+}
+
+func (p *Publisher) onError(err error) {
+	if p.config.OnError != nil {
+		p.config.OnError(err)
+	}
+}
@@ -0,0 +1,221 @@
+package mqtt
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+	"github.com/streamingfast/imu-controller/replay/replaytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var start = replaytest.Start
+
+func newHub(t *testing.T, device iim42652.Device) *hub.Hub {
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	h, err := hub.New(device, hub.DefaultConfig(transform))
+	require.NoError(t, err)
+	return h
+}
+
+type doneToken struct {
+	paho.Token
+}
+
+func (doneToken) Wait() bool                       { return true }
+func (doneToken) WaitTimeout(_ time.Duration) bool { return true }
+func (doneToken) Error() error                     { return nil }
+
+type message struct {
+	topic    string
+	qos      byte
+	retained bool
+	payload  []byte
+}
+
+type fakeClient struct {
+	lock     sync.Mutex
+	messages []message
+}
+
+func (c *fakeClient) Connect() paho.Token {
+	return doneToken{}
+}
+
+func (c *fakeClient) Publish(topic string, qos byte, retained bool, payload interface{}) paho.Token {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.messages = append(c.messages, message{topic, qos, retained, payload.([]byte)})
+	return doneToken{}
+}
+
+func (c *fakeClient) Disconnect(quiesce uint) {}
+
+func decode[T any, M interface {
+	*T
+	proto.Message
+}](t *testing.T, m message) M {
+	value := M(new(T))
+	require.NoError(t, protojson.Unmarshal(m.payload, value))
+	return value
+}
+
+func Test_Publisher(t *testing.T) {
+	device := replaytest.NewGatedDevice(t)
+	h := newHub(t, device)
+	client := &fakeClient{}
+	p := &Publisher{config: DefaultConfig("tcp://localhost:1883"), hub: h, client: client}
+
+	publisherDone := make(chan error)
+	go func() { publisherDone <- p.Run(context.Background()) }()
+	require.Eventually(t, func() bool { return h.Subscribers() == 1 }, time.Second, time.Millisecond)
+	device.Start()
+	require.NoError(t, h.Run(context.Background()))
+	require.NoError(t, <-publisherDone)
+
+	require.Len(t, client.messages, 3)
+	for _, m := range client.messages {
+		assert.Equal(t, byte(1), m.qos)
+	}
+
+	assert.Equal(t, "imu/motion_state", client.messages[0].topic)
+	assert.True(t, client.messages[0].retained)
+	state := decode[imuv1.Event](t, client.messages[0]).GetMotionState()
+	require.NotNil(t, state)
+	assert.Equal(t, imuv1.MotionState_MOTION_STATE_STATIONARY, state.To)
+	assert.Equal(t, imuv1.MotionState_MOTION_STATE_UNKNOWN, state.From)
+
+	assert.Equal(t, "imu/impact", client.messages[1].topic)
+	assert.False(t, client.messages[1].retained)
+	assert.NotContains(t, string(client.messages[1].payload), "samples")
+	impactEvent := decode[imuv1.Event](t, client.messages[1])
+	impact := impactEvent.GetImpact()
+	require.NotNil(t, impact)
+	assert.Equal(t, start.Add(800*time.Millisecond), impactEvent.Time.AsTime())
+	assert.Equal(t, start.Add(800*time.Millisecond), impact.TriggerTime.AsTime())
+	assert.InDelta(t, 5, impact.PeakMagnitude, 0.01)
+
+	assert.Equal(t, "imu/summary", client.messages[2].topic)
+	summary := decode[imuv1.Summary](t, client.messages[2])
+	assert.Equal(t, uint64(101), summary.Samples)
+	assert.Equal(t, 100.0, summary.SampleRateHz)
+	assert.Equal(t, imuv1.MotionState_MOTION_STATE_STATIONARY, summary.MotionState)
+	assert.Equal(t, uint32(1), summary.Impacts)
+	assert.InDelta(t, 5, summary.PeakImpact, 0.01)
+	assert.Empty(t, summary.DrivingEvents)
+
+	// The current state is published again when the connection is restored.
+	p.onConnect()
+	require.Len(t, client.messages, 4)
+	assert.Equal(t, "imu/motion_state", client.messages[3].topic)
+	assert.True(t, client.messages[3].retained)
+	assert.Equal(t, imuv1.MotionState_MOTION_STATE_STATIONARY, decode[imuv1.Event](t, client.messages[3]).GetMotionState().GetTo())
+}
+
+func Test_Summary(t *testing.T) {
+	client := &fakeClient{}
+	config := DefaultConfig("tcp://localhost:1883")
+	config.DrivingTopic = ""
+	p := &Publisher{config: config, hub: newHub(t, replaytest.NewGatedDevice(t)), client: client, summary: newSummary(start)}
+
+	for _, eventType := range []driving.EventType{driving.EventTypeLeftTurn, driving.EventTypeHardBraking, driving.EventTypeLeftTurn} {
+		p.publishEvent(&events.Event{Type: events.TypeDriving, Driving: &driving.Event{Type: eventType}})
+	}
+	p.publishSummary(start.Add(time.Minute))
+	p.publishSummary(start.Add(2 * time.Minute))
+
+	// Driving events are counted even though they are not published.
+	require.Len(t, client.messages, 2)
+	summary := decode[imuv1.Summary](t, client.messages[0])
+	assert.Equal(t, start, summary.Start.AsTime())
+	assert.Equal(t, start.Add(time.Minute), summary.End.AsTime())
+	assert.Equal(t, map[string]uint32{"DRIVING_EVENT_TYPE_LEFT_TURN": 2, "DRIVING_EVENT_TYPE_HARD_BRAKING": 1}, summary.DrivingEvents)
+	assert.Empty(t, decode[imuv1.Summary](t, client.messages[1]).DrivingEvents)
+}
+
+func Test_Config(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(c *Config)
+	}{
+		{"no broker", func(c *Config) { c.Broker = "" }},
+		{"qos", func(c *Config) { c.QoS = 3 }},
+		{"summary interval", func(c *Config) { c.SummaryInterval = 0 }},
+		{"publish timeout", func(c *Config) { c.PublishTimeout = 0 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig("tcp://localhost:1883")
+			test.update(config)
+			_, err := NewPublisher(nil, config)
+			assert.Error(t, err)
+		})
+	}
+
+	config := DefaultConfig("tcp://localhost:1883")
+	config.SummaryTopic, config.SummaryInterval = "", 0
+	assert.NoError(t, config.Validate())
+}
+
+// Test_Broker runs against the broker at MQTT_BROKER, like
+// tcp://localhost:1883.
+func Test_Broker(t *testing.T) {
+	broker := os.Getenv("MQTT_BROKER")
+	if broker == "" {
+		t.Skip("MQTT_BROKER is not set")
+	}
+
+	device := replaytest.NewGatedDevice(t)
+	h := newHub(t, device)
+	config := DefaultConfig(broker)
+	config.ClientID = "imu-controller-test"
+	config.MotionStateTopic = "imu-controller-test/motion_state"
+	config.ImpactTopic = "imu-controller-test/impact"
+	config.SummaryTopic = "imu-controller-test/summary"
+	config.OnError = func(err error) { t.Error(err) }
+	p, err := NewPublisher(h, config)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisherDone := make(chan error)
+	go func() { publisherDone <- p.Run(ctx) }()
+	require.Eventually(t, func() bool { return h.Subscribers() == 1 }, time.Second, time.Millisecond)
+	device.Start()
+	require.NoError(t, h.Run(ctx))
+	require.NoError(t, <-publisherDone)
+
+	// A subscriber connecting later receives the retained motion state.
+	subscriber := paho.NewClient(paho.NewClientOptions().AddBroker(broker).SetClientID("imu-controller-test-subscriber"))
+	require.True(t, subscriber.Connect().WaitTimeout(5*time.Second))
+	defer subscriber.Disconnect(250)
+	states := make(chan *imuv1.Event, 1)
+	token := subscriber.Subscribe(config.MotionStateTopic, 1, func(_ paho.Client, m paho.Message) {
+		state := &imuv1.Event{}
+		if protojson.Unmarshal(m.Payload(), state) == nil && m.Retained() {
+			states <- state
+		}
+	})
+	require.True(t, token.WaitTimeout(5*time.Second))
+	require.NoError(t, token.Error())
+
+	select {
+	case state := <-states:
+		assert.Equal(t, imuv1.MotionState_MOTION_STATE_STATIONARY, state.GetMotionState().GetTo())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no retained motion state")
+	}
+}
@@ -0,0 +1,259 @@
+package imuv1
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/detector/impact"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversions between the messages and the types of this repository. They
+// are maintained by hand, update them along with imu/v1/imu.proto.
+
+var (
+	motionStates = map[motion.State]MotionState{
+		motion.StateUnknown:    MotionState_MOTION_STATE_UNKNOWN,
+		motion.StateMoving:     MotionState_MOTION_STATE_MOVING,
+		motion.StateStationary: MotionState_MOTION_STATE_STATIONARY,
+		motion.StateParked:     MotionState_MOTION_STATE_PARKED,
+	}
+	drivingEventTypes = map[driving.EventType]DrivingEventType{
+		driving.EventTypeLeftTurn:         DrivingEventType_DRIVING_EVENT_TYPE_LEFT_TURN,
+		driving.EventTypeRightTurn:        DrivingEventType_DRIVING_EVENT_TYPE_RIGHT_TURN,
+		driving.EventTypeHardAcceleration: DrivingEventType_DRIVING_EVENT_TYPE_HARD_ACCELERATION,
+		driving.EventTypeHardBraking:      DrivingEventType_DRIVING_EVENT_TYPE_HARD_BRAKING,
+	}
+	eventTypes = map[events.Type]EventType{
+		events.TypeMotionState: EventType_EVENT_TYPE_MOTION_STATE,
+		events.TypeDriving:     EventType_EVENT_TYPE_DRIVING,
+		events.TypeImpact:      EventType_EVENT_TYPE_IMPACT,
+	}
+)
+
+func NewVector(v [3]float64) *Vector {
+	return &Vector{X: v[0], Y: v[1], Z: v[2]}
+}
+
+func (v *Vector) ToArray() [3]float64 {
+	return [3]float64{v.GetX(), v.GetY(), v.GetZ()}
+}
+
+func NewRawVector(v [3]int16) *RawVector {
+	return &RawVector{X: int32(v[0]), Y: int32(v[1]), Z: int32(v[2])}
+}
+
+// ToArray truncates the values to 16 bits, the size of the device registers.
+func (v *RawVector) ToArray() [3]int16 {
+	return [3]int16{int16(v.GetX()), int16(v.GetY()), int16(v.GetZ())}
+}
+
+// NewSample fills the camera frame values when transform is set.
+func NewSample(sample *iim42652.Sample, transform *iim42652.MountTransform) *Sample {
+	acceleration, angularRate := sample.Acceleration, sample.AngularRate
+	s := &Sample{
+		Time:            timestamppb.New(sample.Time),
+		RawAcceleration: NewRawVector([3]int16{acceleration.RawX, acceleration.RawY, acceleration.RawZ}),
+		RawAngularRate:  NewRawVector([3]int16{angularRate.RawX, angularRate.RawY, angularRate.RawZ}),
+		RawTemperature:  int32(sample.RawTemperature),
+		Acceleration:    NewVector([3]float64{acceleration.X, acceleration.Y, acceleration.Z}),
+		AngularRate:     NewVector([3]float64{angularRate.X, angularRate.Y, angularRate.Z}),
+		Temperature:     sample.Temperature,
+	}
+	if transform != nil {
+		s.CameraAcceleration = NewVector(transform.Acceleration(acceleration))
+		s.CameraAngularRate = NewVector(transform.AngularRate(angularRate))
+	}
+	return s
+}
+
+// ToSample returns the values along the IMU axes, the camera frame values are
+// dropped.
+func (s *Sample) ToSample() *iim42652.Sample {
+	rawAcceleration, acceleration := s.GetRawAcceleration().ToArray(), s.GetAcceleration().ToArray()
+	rawAngularRate, angularRate := s.GetRawAngularRate().ToArray(), s.GetAngularRate().ToArray()
+	return &iim42652.Sample{
+		Time: s.GetTime().AsTime(),
+		Acceleration: &iim42652.Acceleration{
+			RawX: rawAcceleration[0], RawY: rawAcceleration[1], RawZ: rawAcceleration[2],
+			X: acceleration[0], Y: acceleration[1], Z: acceleration[2],
+			TotalMagnitude: math.Sqrt(acceleration[0]*acceleration[0] + acceleration[1]*acceleration[1] + acceleration[2]*acceleration[2]),
+		},
+		AngularRate: &iim42652.AngularRate{
+			RawX: rawAngularRate[0], RawY: rawAngularRate[1], RawZ: rawAngularRate[2],
+			X: angularRate[0], Y: angularRate[1], Z: angularRate[2],
+		},
+		RawTemperature: int16(s.GetRawTemperature()),
+		Temperature:    s.GetTemperature(),
+	}
+}
+
+func NewDeviceConfiguration(configuration *iim42652.Configuration) *DeviceConfiguration {
+	return &DeviceConfiguration{
+		AccelerometerFullScaleG:        configuration.AccelerometerFullScale,
+		AccelerometerOdrHz:             configuration.AccelerometerODR,
+		GyroFullScaleDps:               configuration.GyroFullScale,
+		GyroOdrHz:                      configuration.GyroODR,
+		GyroOffsets:                    NewRawVector(configuration.GyroOffsets),
+		AccelerometerOffsets:           NewRawVector(configuration.AccelerometerOffsets),
+		AccelerationSensitivityGPerLsb: float64(configuration.AccelerationSensitivity),
+		GyroScaleDpsPerLsb:             float64(configuration.GyroScale),
+	}
+}
+
+func (c *DeviceConfiguration) ToConfiguration() *iim42652.Configuration {
+	return &iim42652.Configuration{
+		AccelerometerFullScale:  c.GetAccelerometerFullScaleG(),
+		AccelerometerODR:        c.GetAccelerometerOdrHz(),
+		GyroFullScale:           c.GetGyroFullScaleDps(),
+		GyroODR:                 c.GetGyroOdrHz(),
+		GyroOffsets:             c.GetGyroOffsets().ToArray(),
+		AccelerometerOffsets:    c.GetAccelerometerOffsets().ToArray(),
+		AccelerationSensitivity: iim42652.AccelerationSensitivity(c.GetAccelerationSensitivityGPerLsb()),
+		GyroScale:               iim42652.GyroScale(c.GetGyroScaleDpsPerLsb()),
+	}
+}
+
+// NewCalibration builds the calibration profile of a device from its
+// configuration and mounting.
+func NewCalibration(configuration *iim42652.Configuration, transform *iim42652.MountTransform) *Calibration {
+	calibration := &Calibration{
+		GyroOffsets:             NewVector(configuration.GyroOffsetsDps()),
+		AccelerometerOffsets:    NewVector(configuration.AccelerometerOffsetsG()),
+		RawGyroOffsets:          NewRawVector(configuration.GyroOffsets),
+		RawAccelerometerOffsets: NewRawVector(configuration.AccelerometerOffsets),
+	}
+	for _, row := range transform.Rotation() {
+		calibration.Mounting = append(calibration.Mounting, row[:]...)
+	}
+	return calibration
+}
+
+// ToMountTransform validates the mounting rotation.
+func (c *Calibration) ToMountTransform() (*iim42652.MountTransform, error) {
+	if len(c.GetMounting()) != 9 {
+		return nil, fmt.Errorf("mounting must hold 9 values, got %d", len(c.GetMounting()))
+	}
+	var rotation iim42652.RotationMatrix
+	for row := range rotation {
+		copy(rotation[row][:], c.GetMounting()[3*row:])
+	}
+	return iim42652.NewMountTransform(rotation)
+}
+
+func NewMotionState(state motion.State) MotionState {
+	return motionStates[state]
+}
+
+func (s MotionState) ToState() motion.State {
+	for state, value := range motionStates {
+		if value == s {
+			return state
+		}
+	}
+	return motion.StateUnknown
+}
+
+func NewDrivingEventType(eventType driving.EventType) DrivingEventType {
+	return drivingEventTypes[eventType]
+}
+
+func NewDrivingEvent(event *driving.Event) *DrivingEvent {
+	return &DrivingEvent{
+		Type:  NewDrivingEventType(event.Type),
+		Start: timestamppb.New(event.Start),
+		End:   timestamppb.New(event.End),
+		Peak:  event.Peak,
+	}
+}
+
+// ToEvent returns an event with an empty type when the type is unspecified.
+func (e *DrivingEvent) ToEvent() *driving.Event {
+	event := &driving.Event{
+		Start: e.GetStart().AsTime(),
+		End:   e.GetEnd().AsTime(),
+		Peak:  e.GetPeak(),
+	}
+	event.Duration = event.End.Sub(event.Start)
+	for eventType, value := range drivingEventTypes {
+		if value == e.GetType() {
+			event.Type = eventType
+		}
+	}
+	return event
+}
+
+func NewImpact(event *impact.Event) *Impact {
+	return &Impact{
+		TriggerTime:      timestamppb.New(event.TriggerTime()),
+		PeakTime:         timestamppb.New(event.PeakTime()),
+		PeakAcceleration: NewVector(event.PeakAcceleration()),
+		PeakMagnitude:    event.PeakMagnitude(),
+		PeakJerk:         event.PeakJerk(),
+		Direction:        event.Direction(),
+		PeakAngularRate:  NewVector(event.PeakAngularRate()),
+	}
+}
+
+func NewEventType(eventType events.Type) EventType {
+	return eventTypes[eventType]
+}
+
+// ToType returns false when the type is unspecified.
+func (t EventType) ToType() (events.Type, bool) {
+	for eventType, value := range eventTypes {
+		if value == t {
+			return eventType, true
+		}
+	}
+	return "", false
+}
+
+func NewEvent(event *events.Event) *Event {
+	e := &Event{Time: timestamppb.New(event.Time)}
+	switch event.Type {
+	case events.TypeMotionState:
+		e.Event = &Event_MotionState{MotionState: &MotionStateChange{
+			From: NewMotionState(event.MotionState.From),
+			To:   NewMotionState(event.MotionState.To),
+		}}
+	case events.TypeDriving:
+		e.Event = &Event_Driving{Driving: NewDrivingEvent(event.Driving)}
+	case events.TypeImpact:
+		e.Event = &Event_Impact{Impact: NewImpact(event.Impact)}
+	}
+	return e
+}
+
+func NewConfig(configuration *iim42652.Configuration, period time.Duration) *Config {
+	return &Config{
+		AccelerometerFullScaleG: configuration.AccelerometerFullScale,
+		AccelerometerOdrHz:      configuration.AccelerometerODR,
+		GyroFullScaleDps:        configuration.GyroFullScale,
+		GyroOdrHz:               configuration.GyroODR,
+		SamplePeriod:            durationpb.New(period),
+	}
+}
+
+func NewSensorSelfTest(test *iim42652.SensorSelfTest) *SensorSelfTest {
+	return &SensorSelfTest{
+		Response:        &Vector{X: test.Response.X, Y: test.Response.Y, Z: test.Response.Z},
+		FactoryResponse: &Vector{X: test.FactoryResponse.X, Y: test.FactoryResponse.Y, Z: test.FactoryResponse.Z},
+		Passed:          test.Passed,
+		Failures:        test.Failures,
+	}
+}
+
+func NewSelfTestReport(report *iim42652.SelfTestReport) *SelfTestReport {
+	return &SelfTestReport{
+		Gyro:          NewSensorSelfTest(report.Gyro),
+		Accelerometer: NewSensorSelfTest(report.Accelerometer),
+		Passed:        report.Passed,
+	}
+}
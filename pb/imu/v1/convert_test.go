@@ -0,0 +1,119 @@
+package imuv1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/detector/driving"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var start = time.Unix(1685620800, 0).UTC()
+
+func Test_Sample(t *testing.T) {
+	sample := &iim42652.Sample{
+		Time:           start,
+		Acceleration:   iim42652.NewAcceleration(100, -200, 2048, iim42652.AccelerationSensitivityG16),
+		AngularRate:    iim42652.NewGyroscope(-3, 4, 5, iim42652.GyroScalesG2000),
+		RawTemperature: 1200,
+		Temperature:    iim42652.ConvertRawTemperature(1200),
+	}
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+
+	message := NewSample(sample, transform)
+	assert.Equal(t, transform.Acceleration(sample.Acceleration), message.CameraAcceleration.ToArray())
+	assert.Nil(t, NewSample(sample, nil).CameraAcceleration)
+
+	// The messages survive the wire.
+	data, err := proto.Marshal(message)
+	require.NoError(t, err)
+	decoded := &Sample{}
+	require.NoError(t, proto.Unmarshal(data, decoded))
+	assert.Equal(t, sample, decoded.ToSample())
+}
+
+func Test_DeviceConfiguration(t *testing.T) {
+	configuration := &iim42652.Configuration{
+		AccelerometerFullScale:  16,
+		AccelerometerODR:        100,
+		GyroFullScale:           2000,
+		GyroODR:                 1000,
+		GyroOffsets:             [3]int16{-32, 64, 2047},
+		AccelerometerOffsets:    [3]int16{-2048, 0, 10},
+		AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+		GyroScale:               iim42652.GyroScalesG2000,
+	}
+	assert.Equal(t, configuration, NewDeviceConfiguration(configuration).ToConfiguration())
+}
+
+func Test_Calibration(t *testing.T) {
+	configuration := &iim42652.Configuration{
+		GyroOffsets:          [3]int16{32, -64, 0},
+		AccelerometerOffsets: [3]int16{2000, 0, -1000},
+	}
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+
+	calibration := NewCalibration(configuration, transform)
+	assert.Equal(t, [3]float64{1, -2, 0}, calibration.GyroOffsets.ToArray())
+	assert.Equal(t, [3]float64{1, 0, -0.5}, calibration.AccelerometerOffsets.ToArray())
+	assert.Equal(t, configuration.GyroOffsets, calibration.RawGyroOffsets.ToArray())
+	assert.Equal(t, configuration.AccelerometerOffsets, calibration.RawAccelerometerOffsets.ToArray())
+
+	mounting, err := calibration.ToMountTransform()
+	require.NoError(t, err)
+	assert.Equal(t, transform.Rotation(), mounting.Rotation())
+
+	_, err = (&Calibration{Mounting: []float64{1, 0, 0}}).ToMountTransform()
+	assert.Error(t, err)
+	_, err = (&Calibration{Mounting: make([]float64, 9)}).ToMountTransform()
+	assert.Error(t, err)
+}
+
+func Test_Events(t *testing.T) {
+	for _, state := range []motion.State{motion.StateUnknown, motion.StateMoving, motion.StateStationary, motion.StateParked} {
+		assert.Equal(t, state, NewMotionState(state).ToState())
+	}
+
+	for _, eventType := range []events.Type{events.TypeMotionState, events.TypeDriving, events.TypeImpact} {
+		back, found := NewEventType(eventType).ToType()
+		assert.True(t, found)
+		assert.Equal(t, eventType, back)
+	}
+	_, found := EventType_EVENT_TYPE_UNSPECIFIED.ToType()
+	assert.False(t, found)
+
+	drivingEvent := &driving.Event{
+		Type:     driving.EventTypeHardBraking,
+		Start:    start,
+		End:      start.Add(1500 * time.Millisecond),
+		Duration: 1500 * time.Millisecond,
+		Peak:     -0.45,
+	}
+	assert.Equal(t, drivingEvent, NewDrivingEvent(drivingEvent).ToEvent())
+
+	event := NewEvent(&events.Event{Type: events.TypeDriving, Time: drivingEvent.End, Driving: drivingEvent})
+	assert.Equal(t, DrivingEventType_DRIVING_EVENT_TYPE_HARD_BRAKING, event.GetDriving().Type)
+	event = NewEvent(&events.Event{
+		Type:        events.TypeMotionState,
+		Time:        start,
+		MotionState: &motion.StateChange{Time: start, From: motion.StateMoving, To: motion.StateStationary},
+	})
+	assert.Equal(t, MotionState_MOTION_STATE_STATIONARY, event.GetMotionState().To)
+
+	payload, err := JSON.Marshal(event)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, map[string]any{
+		"time":         "2023-06-01T12:00:00Z",
+		"motion_state": map[string]any{"from": "MOTION_STATE_MOVING", "to": "MOTION_STATE_STATIONARY"},
+	}, decoded)
+}
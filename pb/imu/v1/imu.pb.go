@@ -0,0 +1,1600 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: imu/v1/imu.proto
+
+package imuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED  EventType = 0
+	EventType_EVENT_TYPE_MOTION_STATE EventType = 1
+	EventType_EVENT_TYPE_DRIVING      EventType = 2
+	EventType_EVENT_TYPE_IMPACT       EventType = 3
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_MOTION_STATE",
+		2: "EVENT_TYPE_DRIVING",
+		3: "EVENT_TYPE_IMPACT",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":  0,
+		"EVENT_TYPE_MOTION_STATE": 1,
+		"EVENT_TYPE_DRIVING":      2,
+		"EVENT_TYPE_IMPACT":       3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_imu_v1_imu_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_imu_v1_imu_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{0}
+}
+
+type MotionState int32
+
+const (
+	MotionState_MOTION_STATE_UNKNOWN    MotionState = 0
+	MotionState_MOTION_STATE_MOVING     MotionState = 1
+	MotionState_MOTION_STATE_STATIONARY MotionState = 2
+	MotionState_MOTION_STATE_PARKED     MotionState = 3
+)
+
+// Enum value maps for MotionState.
+var (
+	MotionState_name = map[int32]string{
+		0: "MOTION_STATE_UNKNOWN",
+		1: "MOTION_STATE_MOVING",
+		2: "MOTION_STATE_STATIONARY",
+		3: "MOTION_STATE_PARKED",
+	}
+	MotionState_value = map[string]int32{
+		"MOTION_STATE_UNKNOWN":    0,
+		"MOTION_STATE_MOVING":     1,
+		"MOTION_STATE_STATIONARY": 2,
+		"MOTION_STATE_PARKED":     3,
+	}
+)
+
+func (x MotionState) Enum() *MotionState {
+	p := new(MotionState)
+	*p = x
+	return p
+}
+
+func (x MotionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MotionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_imu_v1_imu_proto_enumTypes[1].Descriptor()
+}
+
+func (MotionState) Type() protoreflect.EnumType {
+	return &file_imu_v1_imu_proto_enumTypes[1]
+}
+
+func (x MotionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MotionState.Descriptor instead.
+func (MotionState) EnumDescriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{1}
+}
+
+type DrivingEventType int32
+
+const (
+	DrivingEventType_DRIVING_EVENT_TYPE_UNSPECIFIED       DrivingEventType = 0
+	DrivingEventType_DRIVING_EVENT_TYPE_LEFT_TURN         DrivingEventType = 1
+	DrivingEventType_DRIVING_EVENT_TYPE_RIGHT_TURN        DrivingEventType = 2
+	DrivingEventType_DRIVING_EVENT_TYPE_HARD_ACCELERATION DrivingEventType = 3
+	DrivingEventType_DRIVING_EVENT_TYPE_HARD_BRAKING      DrivingEventType = 4
+)
+
+// Enum value maps for DrivingEventType.
+var (
+	DrivingEventType_name = map[int32]string{
+		0: "DRIVING_EVENT_TYPE_UNSPECIFIED",
+		1: "DRIVING_EVENT_TYPE_LEFT_TURN",
+		2: "DRIVING_EVENT_TYPE_RIGHT_TURN",
+		3: "DRIVING_EVENT_TYPE_HARD_ACCELERATION",
+		4: "DRIVING_EVENT_TYPE_HARD_BRAKING",
+	}
+	DrivingEventType_value = map[string]int32{
+		"DRIVING_EVENT_TYPE_UNSPECIFIED":       0,
+		"DRIVING_EVENT_TYPE_LEFT_TURN":         1,
+		"DRIVING_EVENT_TYPE_RIGHT_TURN":        2,
+		"DRIVING_EVENT_TYPE_HARD_ACCELERATION": 3,
+		"DRIVING_EVENT_TYPE_HARD_BRAKING":      4,
+	}
+)
+
+func (x DrivingEventType) Enum() *DrivingEventType {
+	p := new(DrivingEventType)
+	*p = x
+	return p
+}
+
+func (x DrivingEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DrivingEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_imu_v1_imu_proto_enumTypes[2].Descriptor()
+}
+
+func (DrivingEventType) Type() protoreflect.EnumType {
+	return &file_imu_v1_imu_proto_enumTypes[2]
+}
+
+func (x DrivingEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DrivingEventType.Descriptor instead.
+func (DrivingEventType) EnumDescriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{2}
+}
+
+type Vector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z float64 `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vector) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Vector) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Vector) GetZ() float64 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+type RawVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z int32 `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *RawVector) Reset() {
+	*x = RawVector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawVector) ProtoMessage() {}
+
+func (x *RawVector) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawVector.ProtoReflect.Descriptor instead.
+func (*RawVector) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RawVector) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *RawVector) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RawVector) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+// Sample values are along the IMU axes unless stated otherwise.
+type Sample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	RawAcceleration *RawVector             `protobuf:"bytes,2,opt,name=raw_acceleration,json=rawAcceleration,proto3" json:"raw_acceleration,omitempty"`
+	RawAngularRate  *RawVector             `protobuf:"bytes,3,opt,name=raw_angular_rate,json=rawAngularRate,proto3" json:"raw_angular_rate,omitempty"`
+	RawTemperature  int32                  `protobuf:"varint,4,opt,name=raw_temperature,json=rawTemperature,proto3" json:"raw_temperature,omitempty"`
+	// In g.
+	Acceleration *Vector `protobuf:"bytes,5,opt,name=acceleration,proto3" json:"acceleration,omitempty"`
+	// In dps.
+	AngularRate *Vector `protobuf:"bytes,6,opt,name=angular_rate,json=angularRate,proto3" json:"angular_rate,omitempty"`
+	// In °C.
+	Temperature float64 `protobuf:"fixed64,7,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	// Along the camera axes (X forward, Y left, Z up), when requested.
+	CameraAcceleration *Vector `protobuf:"bytes,8,opt,name=camera_acceleration,json=cameraAcceleration,proto3" json:"camera_acceleration,omitempty"`
+	CameraAngularRate  *Vector `protobuf:"bytes,9,opt,name=camera_angular_rate,json=cameraAngularRate,proto3" json:"camera_angular_rate,omitempty"`
+}
+
+func (x *Sample) Reset() {
+	*x = Sample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sample) ProtoMessage() {}
+
+func (x *Sample) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sample.ProtoReflect.Descriptor instead.
+func (*Sample) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Sample) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Sample) GetRawAcceleration() *RawVector {
+	if x != nil {
+		return x.RawAcceleration
+	}
+	return nil
+}
+
+func (x *Sample) GetRawAngularRate() *RawVector {
+	if x != nil {
+		return x.RawAngularRate
+	}
+	return nil
+}
+
+func (x *Sample) GetRawTemperature() int32 {
+	if x != nil {
+		return x.RawTemperature
+	}
+	return 0
+}
+
+func (x *Sample) GetAcceleration() *Vector {
+	if x != nil {
+		return x.Acceleration
+	}
+	return nil
+}
+
+func (x *Sample) GetAngularRate() *Vector {
+	if x != nil {
+		return x.AngularRate
+	}
+	return nil
+}
+
+func (x *Sample) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Sample) GetCameraAcceleration() *Vector {
+	if x != nil {
+		return x.CameraAcceleration
+	}
+	return nil
+}
+
+func (x *Sample) GetCameraAngularRate() *Vector {
+	if x != nil {
+		return x.CameraAngularRate
+	}
+	return nil
+}
+
+// DeviceConfiguration is what is needed to convert the raw values of a
+// sample.
+type DeviceConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccelerometerFullScaleG float64 `protobuf:"fixed64,1,opt,name=accelerometer_full_scale_g,json=accelerometerFullScaleG,proto3" json:"accelerometer_full_scale_g,omitempty"`
+	AccelerometerOdrHz      float64 `protobuf:"fixed64,2,opt,name=accelerometer_odr_hz,json=accelerometerOdrHz,proto3" json:"accelerometer_odr_hz,omitempty"`
+	GyroFullScaleDps        float64 `protobuf:"fixed64,3,opt,name=gyro_full_scale_dps,json=gyroFullScaleDps,proto3" json:"gyro_full_scale_dps,omitempty"`
+	GyroOdrHz               float64 `protobuf:"fixed64,4,opt,name=gyro_odr_hz,json=gyroOdrHz,proto3" json:"gyro_odr_hz,omitempty"`
+	// Offsets programmed in the user registers, in register units: 1/32 dps for
+	// the gyro and 0.5 mg for the accelerometer.
+	GyroOffsets          *RawVector `protobuf:"bytes,5,opt,name=gyro_offsets,json=gyroOffsets,proto3" json:"gyro_offsets,omitempty"`
+	AccelerometerOffsets *RawVector `protobuf:"bytes,6,opt,name=accelerometer_offsets,json=accelerometerOffsets,proto3" json:"accelerometer_offsets,omitempty"`
+	// Sensitivities the raw values are converted with.
+	AccelerationSensitivityGPerLsb float64 `protobuf:"fixed64,7,opt,name=acceleration_sensitivity_g_per_lsb,json=accelerationSensitivityGPerLsb,proto3" json:"acceleration_sensitivity_g_per_lsb,omitempty"`
+	GyroScaleDpsPerLsb             float64 `protobuf:"fixed64,8,opt,name=gyro_scale_dps_per_lsb,json=gyroScaleDpsPerLsb,proto3" json:"gyro_scale_dps_per_lsb,omitempty"`
+}
+
+func (x *DeviceConfiguration) Reset() {
+	*x = DeviceConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceConfiguration) ProtoMessage() {}
+
+func (x *DeviceConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceConfiguration.ProtoReflect.Descriptor instead.
+func (*DeviceConfiguration) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeviceConfiguration) GetAccelerometerFullScaleG() float64 {
+	if x != nil {
+		return x.AccelerometerFullScaleG
+	}
+	return 0
+}
+
+func (x *DeviceConfiguration) GetAccelerometerOdrHz() float64 {
+	if x != nil {
+		return x.AccelerometerOdrHz
+	}
+	return 0
+}
+
+func (x *DeviceConfiguration) GetGyroFullScaleDps() float64 {
+	if x != nil {
+		return x.GyroFullScaleDps
+	}
+	return 0
+}
+
+func (x *DeviceConfiguration) GetGyroOdrHz() float64 {
+	if x != nil {
+		return x.GyroOdrHz
+	}
+	return 0
+}
+
+func (x *DeviceConfiguration) GetGyroOffsets() *RawVector {
+	if x != nil {
+		return x.GyroOffsets
+	}
+	return nil
+}
+
+func (x *DeviceConfiguration) GetAccelerometerOffsets() *RawVector {
+	if x != nil {
+		return x.AccelerometerOffsets
+	}
+	return nil
+}
+
+func (x *DeviceConfiguration) GetAccelerationSensitivityGPerLsb() float64 {
+	if x != nil {
+		return x.AccelerationSensitivityGPerLsb
+	}
+	return 0
+}
+
+func (x *DeviceConfiguration) GetGyroScaleDpsPerLsb() float64 {
+	if x != nil {
+		return x.GyroScaleDpsPerLsb
+	}
+	return 0
+}
+
+// Calibration profile of a device: the offsets programmed in its user
+// registers and how it is mounted.
+type Calibration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Offsets programmed in the user registers, added by the device to its
+	// measurements: the negated bias found by the calibration. In dps.
+	GyroOffsets *Vector `protobuf:"bytes,1,opt,name=gyro_offsets,json=gyroOffsets,proto3" json:"gyro_offsets,omitempty"`
+	// In g.
+	AccelerometerOffsets *Vector `protobuf:"bytes,2,opt,name=accelerometer_offsets,json=accelerometerOffsets,proto3" json:"accelerometer_offsets,omitempty"`
+	// Rotation from the IMU to the camera frame, 9 values row by row.
+	Mounting []float64 `protobuf:"fixed64,3,rep,packed,name=mounting,proto3" json:"mounting,omitempty"`
+	// The offsets in register units, see DeviceConfiguration.
+	RawGyroOffsets          *RawVector `protobuf:"bytes,4,opt,name=raw_gyro_offsets,json=rawGyroOffsets,proto3" json:"raw_gyro_offsets,omitempty"`
+	RawAccelerometerOffsets *RawVector `protobuf:"bytes,5,opt,name=raw_accelerometer_offsets,json=rawAccelerometerOffsets,proto3" json:"raw_accelerometer_offsets,omitempty"`
+}
+
+func (x *Calibration) Reset() {
+	*x = Calibration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Calibration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calibration) ProtoMessage() {}
+
+func (x *Calibration) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calibration.ProtoReflect.Descriptor instead.
+func (*Calibration) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Calibration) GetGyroOffsets() *Vector {
+	if x != nil {
+		return x.GyroOffsets
+	}
+	return nil
+}
+
+func (x *Calibration) GetAccelerometerOffsets() *Vector {
+	if x != nil {
+		return x.AccelerometerOffsets
+	}
+	return nil
+}
+
+func (x *Calibration) GetMounting() []float64 {
+	if x != nil {
+		return x.Mounting
+	}
+	return nil
+}
+
+func (x *Calibration) GetRawGyroOffsets() *RawVector {
+	if x != nil {
+		return x.RawGyroOffsets
+	}
+	return nil
+}
+
+func (x *Calibration) GetRawAccelerometerOffsets() *RawVector {
+	if x != nil {
+		return x.RawAccelerometerOffsets
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are assignable to Event:
+	//	*Event_MotionState
+	//	*Event_Driving
+	//	*Event_Impact
+	Event isEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (m *Event) GetEvent() isEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *Event) GetMotionState() *MotionStateChange {
+	if x, ok := x.GetEvent().(*Event_MotionState); ok {
+		return x.MotionState
+	}
+	return nil
+}
+
+func (x *Event) GetDriving() *DrivingEvent {
+	if x, ok := x.GetEvent().(*Event_Driving); ok {
+		return x.Driving
+	}
+	return nil
+}
+
+func (x *Event) GetImpact() *Impact {
+	if x, ok := x.GetEvent().(*Event_Impact); ok {
+		return x.Impact
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_MotionState struct {
+	MotionState *MotionStateChange `protobuf:"bytes,2,opt,name=motion_state,json=motionState,proto3,oneof"`
+}
+
+type Event_Driving struct {
+	Driving *DrivingEvent `protobuf:"bytes,3,opt,name=driving,proto3,oneof"`
+}
+
+type Event_Impact struct {
+	Impact *Impact `protobuf:"bytes,4,opt,name=impact,proto3,oneof"`
+}
+
+func (*Event_MotionState) isEvent_Event() {}
+
+func (*Event_Driving) isEvent_Event() {}
+
+func (*Event_Impact) isEvent_Event() {}
+
+type MotionStateChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From MotionState `protobuf:"varint,1,opt,name=from,proto3,enum=imu.v1.MotionState" json:"from,omitempty"`
+	To   MotionState `protobuf:"varint,2,opt,name=to,proto3,enum=imu.v1.MotionState" json:"to,omitempty"`
+}
+
+func (x *MotionStateChange) Reset() {
+	*x = MotionStateChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MotionStateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MotionStateChange) ProtoMessage() {}
+
+func (x *MotionStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MotionStateChange.ProtoReflect.Descriptor instead.
+func (*MotionStateChange) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MotionStateChange) GetFrom() MotionState {
+	if x != nil {
+		return x.From
+	}
+	return MotionState_MOTION_STATE_UNKNOWN
+}
+
+func (x *MotionStateChange) GetTo() MotionState {
+	if x != nil {
+		return x.To
+	}
+	return MotionState_MOTION_STATE_UNKNOWN
+}
+
+type DrivingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  DrivingEventType       `protobuf:"varint,1,opt,name=type,proto3,enum=imu.v1.DrivingEventType" json:"type,omitempty"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Signed acceleration furthest from zero on the event axis, in g.
+	Peak float64 `protobuf:"fixed64,4,opt,name=peak,proto3" json:"peak,omitempty"`
+}
+
+func (x *DrivingEvent) Reset() {
+	*x = DrivingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrivingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrivingEvent) ProtoMessage() {}
+
+func (x *DrivingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrivingEvent.ProtoReflect.Descriptor instead.
+func (*DrivingEvent) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DrivingEvent) GetType() DrivingEventType {
+	if x != nil {
+		return x.Type
+	}
+	return DrivingEventType_DRIVING_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *DrivingEvent) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *DrivingEvent) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *DrivingEvent) GetPeak() float64 {
+	if x != nil {
+		return x.Peak
+	}
+	return 0
+}
+
+// Impact values are along the camera axes.
+type Impact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TriggerTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=trigger_time,json=triggerTime,proto3" json:"trigger_time,omitempty"`
+	PeakTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=peak_time,json=peakTime,proto3" json:"peak_time,omitempty"`
+	// In g.
+	PeakAcceleration *Vector `protobuf:"bytes,3,opt,name=peak_acceleration,json=peakAcceleration,proto3" json:"peak_acceleration,omitempty"`
+	// In g/s.
+	PeakJerk float64 `protobuf:"fixed64,4,opt,name=peak_jerk,json=peakJerk,proto3" json:"peak_jerk,omitempty"`
+	// Direction the impact came from, in degrees: 0 is the front, 90 the left
+	// side, 180 the rear and -90 the right side.
+	Direction float64 `protobuf:"fixed64,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	// In dps.
+	PeakAngularRate *Vector `protobuf:"bytes,6,opt,name=peak_angular_rate,json=peakAngularRate,proto3" json:"peak_angular_rate,omitempty"`
+	// Magnitude of peak_acceleration, in g.
+	PeakMagnitude float64 `protobuf:"fixed64,7,opt,name=peak_magnitude,json=peakMagnitude,proto3" json:"peak_magnitude,omitempty"`
+}
+
+func (x *Impact) Reset() {
+	*x = Impact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Impact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Impact) ProtoMessage() {}
+
+func (x *Impact) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Impact.ProtoReflect.Descriptor instead.
+func (*Impact) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Impact) GetTriggerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TriggerTime
+	}
+	return nil
+}
+
+func (x *Impact) GetPeakTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeakTime
+	}
+	return nil
+}
+
+func (x *Impact) GetPeakAcceleration() *Vector {
+	if x != nil {
+		return x.PeakAcceleration
+	}
+	return nil
+}
+
+func (x *Impact) GetPeakJerk() float64 {
+	if x != nil {
+		return x.PeakJerk
+	}
+	return 0
+}
+
+func (x *Impact) GetDirection() float64 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *Impact) GetPeakAngularRate() *Vector {
+	if x != nil {
+		return x.PeakAngularRate
+	}
+	return nil
+}
+
+func (x *Impact) GetPeakMagnitude() float64 {
+	if x != nil {
+		return x.PeakMagnitude
+	}
+	return 0
+}
+
+// Summary of the activity of the daemon over a period, published to MQTT. The
+// counts cover the time since the previous summary.
+type Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Samples      uint64                 `protobuf:"varint,3,opt,name=samples,proto3" json:"samples,omitempty"`
+	ReadErrors   uint64                 `protobuf:"varint,4,opt,name=read_errors,json=readErrors,proto3" json:"read_errors,omitempty"`
+	SampleRateHz float64                `protobuf:"fixed64,5,opt,name=sample_rate_hz,json=sampleRateHz,proto3" json:"sample_rate_hz,omitempty"`
+	MotionState  MotionState            `protobuf:"varint,6,opt,name=motion_state,json=motionState,proto3,enum=imu.v1.MotionState" json:"motion_state,omitempty"`
+	// Number of driving events by DrivingEventType name.
+	DrivingEvents map[string]uint32 `protobuf:"bytes,7,rep,name=driving_events,json=drivingEvents,proto3" json:"driving_events,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Impacts       uint32            `protobuf:"varint,8,opt,name=impacts,proto3" json:"impacts,omitempty"`
+	// Largest impact peak magnitude, in g.
+	PeakImpact float64 `protobuf:"fixed64,9,opt,name=peak_impact,json=peakImpact,proto3" json:"peak_impact,omitempty"`
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Summary) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Summary) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Summary) GetSamples() uint64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *Summary) GetReadErrors() uint64 {
+	if x != nil {
+		return x.ReadErrors
+	}
+	return 0
+}
+
+func (x *Summary) GetSampleRateHz() float64 {
+	if x != nil {
+		return x.SampleRateHz
+	}
+	return 0
+}
+
+func (x *Summary) GetMotionState() MotionState {
+	if x != nil {
+		return x.MotionState
+	}
+	return MotionState_MOTION_STATE_UNKNOWN
+}
+
+func (x *Summary) GetDrivingEvents() map[string]uint32 {
+	if x != nil {
+		return x.DrivingEvents
+	}
+	return nil
+}
+
+func (x *Summary) GetImpacts() uint32 {
+	if x != nil {
+		return x.Impacts
+	}
+	return 0
+}
+
+func (x *Summary) GetPeakImpact() float64 {
+	if x != nil {
+		return x.PeakImpact
+	}
+	return 0
+}
+
+// Message of the WebSocket stream of the web package.
+type LiveMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*LiveMessage_Sample
+	//	*LiveMessage_Event
+	Message isLiveMessage_Message `protobuf_oneof:"message"`
+}
+
+func (x *LiveMessage) Reset() {
+	*x = LiveMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_imu_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiveMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveMessage) ProtoMessage() {}
+
+func (x *LiveMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_imu_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveMessage.ProtoReflect.Descriptor instead.
+func (*LiveMessage) Descriptor() ([]byte, []int) {
+	return file_imu_v1_imu_proto_rawDescGZIP(), []int{10}
+}
+
+func (m *LiveMessage) GetMessage() isLiveMessage_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *LiveMessage) GetSample() *Sample {
+	if x, ok := x.GetMessage().(*LiveMessage_Sample); ok {
+		return x.Sample
+	}
+	return nil
+}
+
+func (x *LiveMessage) GetEvent() *Event {
+	if x, ok := x.GetMessage().(*LiveMessage_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+type isLiveMessage_Message interface {
+	isLiveMessage_Message()
+}
+
+type LiveMessage_Sample struct {
+	Sample *Sample `protobuf:"bytes,1,opt,name=sample,proto3,oneof"`
+}
+
+type LiveMessage_Event struct {
+	Event *Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+func (*LiveMessage_Sample) isLiveMessage_Message() {}
+
+func (*LiveMessage_Event) isLiveMessage_Message() {}
+
+var File_imu_v1_imu_proto protoreflect.FileDescriptor
+
+var file_imu_v1_imu_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x69, 0x6d, 0x75, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6d, 0x75, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x32, 0x0a, 0x06, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x7a, 0x22,
+	0x35, 0x0a, 0x09, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x0c, 0x0a, 0x01,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x7a, 0x22, 0xe6, 0x03, 0x0a, 0x06, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x3c, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f,
+	0x72, 0x61, 0x77, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3b, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0e, 0x72, 0x61,
+	0x77, 0x41, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0c, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0c, 0x61, 0x6e, 0x67,
+	0x75, 0x6c, 0x61, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52,
+	0x0b, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x3f,
+	0x0a, 0x13, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x12, 0x63, 0x61, 0x6d,
+	0x65, 0x72, 0x61, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3e, 0x0a, 0x13, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61,
+	0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69,
+	0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x61,
+	0x6d, 0x65, 0x72, 0x61, 0x41, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x52, 0x61, 0x74, 0x65, 0x22,
+	0xd1, 0x03, 0x0a, 0x13, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x61, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x73, 0x63,
+	0x61, 0x6c, 0x65, 0x5f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x46, 0x75, 0x6c, 0x6c, 0x53, 0x63,
+	0x61, 0x6c, 0x65, 0x47, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x12, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x4f, 0x64, 0x72, 0x48, 0x7a, 0x12, 0x2d, 0x0a, 0x13, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x66,
+	0x75, 0x6c, 0x6c, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x64, 0x70, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x10, 0x67, 0x79, 0x72, 0x6f, 0x46, 0x75, 0x6c, 0x6c, 0x53, 0x63, 0x61,
+	0x6c, 0x65, 0x44, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x64,
+	0x72, 0x5f, 0x68, 0x7a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x67, 0x79, 0x72, 0x6f,
+	0x4f, 0x64, 0x72, 0x48, 0x7a, 0x12, 0x34, 0x0a, 0x0c, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0b,
+	0x67, 0x79, 0x72, 0x6f, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x15, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x14, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x22, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f,
+	0x67, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6c, 0x73, 0x62, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x1e, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x47, 0x50, 0x65, 0x72, 0x4c, 0x73, 0x62, 0x12,
+	0x32, 0x0a, 0x16, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x64, 0x70,
+	0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6c, 0x73, 0x62, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x12, 0x67, 0x79, 0x72, 0x6f, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x44, 0x70, 0x73, 0x50, 0x65, 0x72,
+	0x4c, 0x73, 0x62, 0x22, 0xad, 0x02, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0c, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x67, 0x79, 0x72, 0x6f, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x43, 0x0a, 0x15, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65,
+	0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x14, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x67,
+	0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x47, 0x79, 0x72, 0x6f, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x19, 0x72, 0x61, 0x77, 0x5f, 0x61, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x61, 0x77, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x17, 0x72, 0x61, 0x77, 0x41,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x73, 0x22, 0xdc, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a,
+	0x0c, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0b, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a,
+	0x07, 0x64, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x64, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x12,
+	0x28, 0x0a, 0x06, 0x69, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x48,
+	0x00, 0x52, 0x06, 0x69, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x61, 0x0a, 0x11, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x23, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x69,
+	0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xb0, 0x01, 0x0a, 0x0c, 0x44, 0x72, 0x69, 0x76, 0x69, 0x6e,
+	0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x72,
+	0x69, 0x76, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x61, 0x6b, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x70, 0x65, 0x61, 0x6b, 0x22, 0xdb, 0x02, 0x0a, 0x06, 0x49, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x70, 0x65, 0x61, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x11, 0x70,
+	0x65, 0x61, 0x6b, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x10, 0x70, 0x65, 0x61, 0x6b, 0x41, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x61, 0x6b,
+	0x5f, 0x6a, 0x65, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x70, 0x65, 0x61,
+	0x6b, 0x4a, 0x65, 0x72, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x11, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x61, 0x6e, 0x67, 0x75,
+	0x6c, 0x61, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f,
+	0x70, 0x65, 0x61, 0x6b, 0x41, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x6d, 0x61, 0x67, 0x6e, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x65, 0x61, 0x6b, 0x4d, 0x61, 0x67,
+	0x6e, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xca, 0x03, 0x0a, 0x07, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x65,
+	0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x61, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x24, 0x0a,
+	0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x7a, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x48, 0x7a, 0x12, 0x36, 0x0a, 0x0c, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x69, 0x6d, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0b,
+	0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x64,
+	0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x64, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x69, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x61, 0x6b, 0x49, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x1a, 0x40, 0x0a, 0x12, 0x44, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x0b, 0x4c, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x73,
+	0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4d, 0x50, 0x41, 0x43,
+	0x54, 0x10, 0x03, 0x2a, 0x76, 0x0a, 0x0b, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13,
+	0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4d, 0x4f, 0x56,
+	0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52, 0x59,
+	0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x50, 0x41, 0x52, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xca, 0x01, 0x0a, 0x10,
+	0x44, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x22, 0x0a, 0x1e, 0x44, 0x52, 0x49, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x44, 0x52, 0x49, 0x56, 0x49, 0x4e, 0x47, 0x5f,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x5f,
+	0x54, 0x55, 0x52, 0x4e, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x44, 0x52, 0x49, 0x56, 0x49, 0x4e,
+	0x47, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x49, 0x47,
+	0x48, 0x54, 0x5f, 0x54, 0x55, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x28, 0x0a, 0x24, 0x44, 0x52, 0x49,
+	0x56, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x48, 0x41, 0x52, 0x44, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x4c, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x03, 0x12, 0x23, 0x0a, 0x1f, 0x44, 0x52, 0x49, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x42,
+	0x52, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67,
+	0x66, 0x61, 0x73, 0x74, 0x2f, 0x69, 0x6d, 0x75, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2f, 0x70, 0x62, 0x2f, 0x69, 0x6d, 0x75, 0x2f, 0x76, 0x31, 0x3b, 0x69, 0x6d,
+	0x75, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_imu_v1_imu_proto_rawDescOnce sync.Once
+	file_imu_v1_imu_proto_rawDescData = file_imu_v1_imu_proto_rawDesc
+)
+
+func file_imu_v1_imu_proto_rawDescGZIP() []byte {
+	file_imu_v1_imu_proto_rawDescOnce.Do(func() {
+		file_imu_v1_imu_proto_rawDescData = protoimpl.X.CompressGZIP(file_imu_v1_imu_proto_rawDescData)
+	})
+	return file_imu_v1_imu_proto_rawDescData
+}
+
+var file_imu_v1_imu_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_imu_v1_imu_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_imu_v1_imu_proto_goTypes = []interface{}{
+	(EventType)(0),                // 0: imu.v1.EventType
+	(MotionState)(0),              // 1: imu.v1.MotionState
+	(DrivingEventType)(0),         // 2: imu.v1.DrivingEventType
+	(*Vector)(nil),                // 3: imu.v1.Vector
+	(*RawVector)(nil),             // 4: imu.v1.RawVector
+	(*Sample)(nil),                // 5: imu.v1.Sample
+	(*DeviceConfiguration)(nil),   // 6: imu.v1.DeviceConfiguration
+	(*Calibration)(nil),           // 7: imu.v1.Calibration
+	(*Event)(nil),                 // 8: imu.v1.Event
+	(*MotionStateChange)(nil),     // 9: imu.v1.MotionStateChange
+	(*DrivingEvent)(nil),          // 10: imu.v1.DrivingEvent
+	(*Impact)(nil),                // 11: imu.v1.Impact
+	(*Summary)(nil),               // 12: imu.v1.Summary
+	(*LiveMessage)(nil),           // 13: imu.v1.LiveMessage
+	nil,                           // 14: imu.v1.Summary.DrivingEventsEntry
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_imu_v1_imu_proto_depIdxs = []int32{
+	15, // 0: imu.v1.Sample.time:type_name -> google.protobuf.Timestamp
+	4,  // 1: imu.v1.Sample.raw_acceleration:type_name -> imu.v1.RawVector
+	4,  // 2: imu.v1.Sample.raw_angular_rate:type_name -> imu.v1.RawVector
+	3,  // 3: imu.v1.Sample.acceleration:type_name -> imu.v1.Vector
+	3,  // 4: imu.v1.Sample.angular_rate:type_name -> imu.v1.Vector
+	3,  // 5: imu.v1.Sample.camera_acceleration:type_name -> imu.v1.Vector
+	3,  // 6: imu.v1.Sample.camera_angular_rate:type_name -> imu.v1.Vector
+	4,  // 7: imu.v1.DeviceConfiguration.gyro_offsets:type_name -> imu.v1.RawVector
+	4,  // 8: imu.v1.DeviceConfiguration.accelerometer_offsets:type_name -> imu.v1.RawVector
+	3,  // 9: imu.v1.Calibration.gyro_offsets:type_name -> imu.v1.Vector
+	3,  // 10: imu.v1.Calibration.accelerometer_offsets:type_name -> imu.v1.Vector
+	4,  // 11: imu.v1.Calibration.raw_gyro_offsets:type_name -> imu.v1.RawVector
+	4,  // 12: imu.v1.Calibration.raw_accelerometer_offsets:type_name -> imu.v1.RawVector
+	15, // 13: imu.v1.Event.time:type_name -> google.protobuf.Timestamp
+	9,  // 14: imu.v1.Event.motion_state:type_name -> imu.v1.MotionStateChange
+	10, // 15: imu.v1.Event.driving:type_name -> imu.v1.DrivingEvent
+	11, // 16: imu.v1.Event.impact:type_name -> imu.v1.Impact
+	1,  // 17: imu.v1.MotionStateChange.from:type_name -> imu.v1.MotionState
+	1,  // 18: imu.v1.MotionStateChange.to:type_name -> imu.v1.MotionState
+	2,  // 19: imu.v1.DrivingEvent.type:type_name -> imu.v1.DrivingEventType
+	15, // 20: imu.v1.DrivingEvent.start:type_name -> google.protobuf.Timestamp
+	15, // 21: imu.v1.DrivingEvent.end:type_name -> google.protobuf.Timestamp
+	15, // 22: imu.v1.Impact.trigger_time:type_name -> google.protobuf.Timestamp
+	15, // 23: imu.v1.Impact.peak_time:type_name -> google.protobuf.Timestamp
+	3,  // 24: imu.v1.Impact.peak_acceleration:type_name -> imu.v1.Vector
+	3,  // 25: imu.v1.Impact.peak_angular_rate:type_name -> imu.v1.Vector
+	15, // 26: imu.v1.Summary.start:type_name -> google.protobuf.Timestamp
+	15, // 27: imu.v1.Summary.end:type_name -> google.protobuf.Timestamp
+	1,  // 28: imu.v1.Summary.motion_state:type_name -> imu.v1.MotionState
+	14, // 29: imu.v1.Summary.driving_events:type_name -> imu.v1.Summary.DrivingEventsEntry
+	5,  // 30: imu.v1.LiveMessage.sample:type_name -> imu.v1.Sample
+	8,  // 31: imu.v1.LiveMessage.event:type_name -> imu.v1.Event
+	32, // [32:32] is the sub-list for method output_type
+	32, // [32:32] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_imu_v1_imu_proto_init() }
+func file_imu_v1_imu_proto_init() {
+	if File_imu_v1_imu_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_imu_v1_imu_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawVector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Calibration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MotionStateChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrivingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Impact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_imu_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiveMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_imu_v1_imu_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*Event_MotionState)(nil),
+		(*Event_Driving)(nil),
+		(*Event_Impact)(nil),
+	}
+	file_imu_v1_imu_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*LiveMessage_Sample)(nil),
+		(*LiveMessage_Event)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_imu_v1_imu_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_imu_v1_imu_proto_goTypes,
+		DependencyIndexes: file_imu_v1_imu_proto_depIdxs,
+		EnumInfos:         file_imu_v1_imu_proto_enumTypes,
+		MessageInfos:      file_imu_v1_imu_proto_msgTypes,
+	}.Build()
+	File_imu_v1_imu_proto = out.File
+	file_imu_v1_imu_proto_rawDesc = nil
+	file_imu_v1_imu_proto_goTypes = nil
+	file_imu_v1_imu_proto_depIdxs = nil
+}
@@ -0,0 +1,7 @@
+package imuv1
+
+import "google.golang.org/protobuf/encoding/protojson"
+
+// JSON encodes the messages published as JSON, by MQTT and the WebSocket
+// stream: the field names of the schema, and the fields with zero values.
+var JSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
@@ -0,0 +1,803 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: imu/v1/service.proto
+
+package imuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamSamplesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only one sample out of decimation is sent, 0 and 1 send them all.
+	Decimation uint32 `protobuf:"varint,1,opt,name=decimation,proto3" json:"decimation,omitempty"`
+	// Fill the camera frame values of the samples.
+	CameraFrame bool `protobuf:"varint,2,opt,name=camera_frame,json=cameraFrame,proto3" json:"camera_frame,omitempty"`
+}
+
+func (x *StreamSamplesRequest) Reset() {
+	*x = StreamSamplesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSamplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSamplesRequest) ProtoMessage() {}
+
+func (x *StreamSamplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSamplesRequest.ProtoReflect.Descriptor instead.
+func (*StreamSamplesRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamSamplesRequest) GetDecimation() uint32 {
+	if x != nil {
+		return x.Decimation
+	}
+	return 0
+}
+
+func (x *StreamSamplesRequest) GetCameraFrame() bool {
+	if x != nil {
+		return x.CameraFrame
+	}
+	return false
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event types sent, all of them when empty.
+	Types []EventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=imu.v1.EventType" json:"types,omitempty"`
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamEventsRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type GetCalibrationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCalibrationRequest) Reset() {
+	*x = GetCalibrationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCalibrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalibrationRequest) ProtoMessage() {}
+
+func (x *GetCalibrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalibrationRequest.ProtoReflect.Descriptor instead.
+func (*GetCalibrationRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccelerometerFullScaleG float64 `protobuf:"fixed64,1,opt,name=accelerometer_full_scale_g,json=accelerometerFullScaleG,proto3" json:"accelerometer_full_scale_g,omitempty"`
+	AccelerometerOdrHz      float64 `protobuf:"fixed64,2,opt,name=accelerometer_odr_hz,json=accelerometerOdrHz,proto3" json:"accelerometer_odr_hz,omitempty"`
+	GyroFullScaleDps        float64 `protobuf:"fixed64,3,opt,name=gyro_full_scale_dps,json=gyroFullScaleDps,proto3" json:"gyro_full_scale_dps,omitempty"`
+	GyroOdrHz               float64 `protobuf:"fixed64,4,opt,name=gyro_odr_hz,json=gyroOdrHz,proto3" json:"gyro_odr_hz,omitempty"`
+	// Time between two samples read by the daemon.
+	SamplePeriod *durationpb.Duration `protobuf:"bytes,5,opt,name=sample_period,json=samplePeriod,proto3" json:"sample_period,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Config) GetAccelerometerFullScaleG() float64 {
+	if x != nil {
+		return x.AccelerometerFullScaleG
+	}
+	return 0
+}
+
+func (x *Config) GetAccelerometerOdrHz() float64 {
+	if x != nil {
+		return x.AccelerometerOdrHz
+	}
+	return 0
+}
+
+func (x *Config) GetGyroFullScaleDps() float64 {
+	if x != nil {
+		return x.GyroFullScaleDps
+	}
+	return 0
+}
+
+func (x *Config) GetGyroOdrHz() float64 {
+	if x != nil {
+		return x.GyroOdrHz
+	}
+	return 0
+}
+
+func (x *Config) GetSamplePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.SamplePeriod
+	}
+	return nil
+}
+
+type SetConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccelerometerOdrHz *float64 `protobuf:"fixed64,1,opt,name=accelerometer_odr_hz,json=accelerometerOdrHz,proto3,oneof" json:"accelerometer_odr_hz,omitempty"`
+	GyroOdrHz          *float64 `protobuf:"fixed64,2,opt,name=gyro_odr_hz,json=gyroOdrHz,proto3,oneof" json:"gyro_odr_hz,omitempty"`
+}
+
+func (x *SetConfigRequest) Reset() {
+	*x = SetConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigRequest) ProtoMessage() {}
+
+func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetConfigRequest) GetAccelerometerOdrHz() float64 {
+	if x != nil && x.AccelerometerOdrHz != nil {
+		return *x.AccelerometerOdrHz
+	}
+	return 0
+}
+
+func (x *SetConfigRequest) GetGyroOdrHz() float64 {
+	if x != nil && x.GyroOdrHz != nil {
+		return *x.GyroOdrHz
+	}
+	return 0
+}
+
+type SelfTestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SelfTestRequest) Reset() {
+	*x = SelfTestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelfTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestRequest) ProtoMessage() {}
+
+func (x *SelfTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestRequest.ProtoReflect.Descriptor instead.
+func (*SelfTestRequest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+type SensorSelfTest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In dps for the gyro, g for the accelerometer.
+	Response *Vector `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// 0 on the axes not programmed at the factory.
+	FactoryResponse *Vector  `protobuf:"bytes,2,opt,name=factory_response,json=factoryResponse,proto3" json:"factory_response,omitempty"`
+	Passed          bool     `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	Failures        []string `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *SensorSelfTest) Reset() {
+	*x = SensorSelfTest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorSelfTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorSelfTest) ProtoMessage() {}
+
+func (x *SensorSelfTest) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorSelfTest.ProtoReflect.Descriptor instead.
+func (*SensorSelfTest) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SensorSelfTest) GetResponse() *Vector {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SensorSelfTest) GetFactoryResponse() *Vector {
+	if x != nil {
+		return x.FactoryResponse
+	}
+	return nil
+}
+
+func (x *SensorSelfTest) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *SensorSelfTest) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type SelfTestReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gyro          *SensorSelfTest `protobuf:"bytes,1,opt,name=gyro,proto3" json:"gyro,omitempty"`
+	Accelerometer *SensorSelfTest `protobuf:"bytes,2,opt,name=accelerometer,proto3" json:"accelerometer,omitempty"`
+	Passed        bool            `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+}
+
+func (x *SelfTestReport) Reset() {
+	*x = SelfTestReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_imu_v1_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelfTestReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestReport) ProtoMessage() {}
+
+func (x *SelfTestReport) ProtoReflect() protoreflect.Message {
+	mi := &file_imu_v1_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTestReport.ProtoReflect.Descriptor instead.
+func (*SelfTestReport) Descriptor() ([]byte, []int) {
+	return file_imu_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SelfTestReport) GetGyro() *SensorSelfTest {
+	if x != nil {
+		return x.Gyro
+	}
+	return nil
+}
+
+func (x *SelfTestReport) GetAccelerometer() *SensorSelfTest {
+	if x != nil {
+		return x.Accelerometer
+	}
+	return nil
+}
+
+func (x *SelfTestReport) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+var File_imu_v1_service_proto protoreflect.FileDescriptor
+
+var file_imu_v1_service_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x69, 0x6d, 0x75, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10,
+	0x69, 0x6d, 0x75, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6d, 0x75, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x59, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x65,
+	0x63, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6d, 0x65,
+	0x72, 0x61, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x22, 0x3e, 0x0a, 0x13, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x86, 0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x3b, 0x0a, 0x1a, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72,
+	0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x46, 0x75, 0x6c, 0x6c, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x47,
+	0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12,
+	0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x64, 0x72,
+	0x48, 0x7a, 0x12, 0x2d, 0x0a, 0x13, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f,
+	0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x64, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x67, 0x79, 0x72, 0x6f, 0x46, 0x75, 0x6c, 0x6c, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x44, 0x70,
+	0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x67, 0x79, 0x72, 0x6f, 0x4f, 0x64, 0x72, 0x48,
+	0x7a, 0x12, 0x3e, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x22, 0x97, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x14, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65,
+	0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x12, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x64, 0x72, 0x48, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a,
+	0x0b, 0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x67, 0x79, 0x72, 0x6f, 0x4f, 0x64, 0x72, 0x48, 0x7a, 0x88,
+	0x01, 0x01, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x67, 0x79, 0x72, 0x6f, 0x5f, 0x6f, 0x64, 0x72, 0x5f, 0x68, 0x7a, 0x22, 0x11, 0x0a, 0x0f, 0x53,
+	0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xab,
+	0x01, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73,
+	0x74, 0x12, 0x2a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x10, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x92, 0x01, 0x0a,
+	0x0e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x2a, 0x0a, 0x04, 0x67, 0x79, 0x72, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x6c,
+	0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x04, 0x67, 0x79, 0x72, 0x6f, 0x12, 0x3c, 0x0a, 0x0d, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x65,
+	0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x64, 0x32, 0xf5, 0x02, 0x0a, 0x03, 0x49, 0x4d, 0x55, 0x12, 0x3f, 0x0a, 0x0d, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0c, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x6d, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x69, 0x62, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x69, 0x6d,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x35, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x18, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69,
+	0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3b, 0x0a, 0x08,
+	0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x54,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e,
+	0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x69, 0x6d, 0x75, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x62, 0x2f, 0x69, 0x6d, 0x75, 0x2f, 0x76, 0x31, 0x3b, 0x69,
+	0x6d, 0x75, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_imu_v1_service_proto_rawDescOnce sync.Once
+	file_imu_v1_service_proto_rawDescData = file_imu_v1_service_proto_rawDesc
+)
+
+func file_imu_v1_service_proto_rawDescGZIP() []byte {
+	file_imu_v1_service_proto_rawDescOnce.Do(func() {
+		file_imu_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_imu_v1_service_proto_rawDescData)
+	})
+	return file_imu_v1_service_proto_rawDescData
+}
+
+var file_imu_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_imu_v1_service_proto_goTypes = []interface{}{
+	(*StreamSamplesRequest)(nil),  // 0: imu.v1.StreamSamplesRequest
+	(*StreamEventsRequest)(nil),   // 1: imu.v1.StreamEventsRequest
+	(*GetCalibrationRequest)(nil), // 2: imu.v1.GetCalibrationRequest
+	(*GetConfigRequest)(nil),      // 3: imu.v1.GetConfigRequest
+	(*Config)(nil),                // 4: imu.v1.Config
+	(*SetConfigRequest)(nil),      // 5: imu.v1.SetConfigRequest
+	(*SelfTestRequest)(nil),       // 6: imu.v1.SelfTestRequest
+	(*SensorSelfTest)(nil),        // 7: imu.v1.SensorSelfTest
+	(*SelfTestReport)(nil),        // 8: imu.v1.SelfTestReport
+	(EventType)(0),                // 9: imu.v1.EventType
+	(*durationpb.Duration)(nil),   // 10: google.protobuf.Duration
+	(*Vector)(nil),                // 11: imu.v1.Vector
+	(*Sample)(nil),                // 12: imu.v1.Sample
+	(*Event)(nil),                 // 13: imu.v1.Event
+	(*Calibration)(nil),           // 14: imu.v1.Calibration
+}
+var file_imu_v1_service_proto_depIdxs = []int32{
+	9,  // 0: imu.v1.StreamEventsRequest.types:type_name -> imu.v1.EventType
+	10, // 1: imu.v1.Config.sample_period:type_name -> google.protobuf.Duration
+	11, // 2: imu.v1.SensorSelfTest.response:type_name -> imu.v1.Vector
+	11, // 3: imu.v1.SensorSelfTest.factory_response:type_name -> imu.v1.Vector
+	7,  // 4: imu.v1.SelfTestReport.gyro:type_name -> imu.v1.SensorSelfTest
+	7,  // 5: imu.v1.SelfTestReport.accelerometer:type_name -> imu.v1.SensorSelfTest
+	0,  // 6: imu.v1.IMU.StreamSamples:input_type -> imu.v1.StreamSamplesRequest
+	1,  // 7: imu.v1.IMU.StreamEvents:input_type -> imu.v1.StreamEventsRequest
+	2,  // 8: imu.v1.IMU.GetCalibration:input_type -> imu.v1.GetCalibrationRequest
+	3,  // 9: imu.v1.IMU.GetConfig:input_type -> imu.v1.GetConfigRequest
+	5,  // 10: imu.v1.IMU.SetConfig:input_type -> imu.v1.SetConfigRequest
+	6,  // 11: imu.v1.IMU.SelfTest:input_type -> imu.v1.SelfTestRequest
+	12, // 12: imu.v1.IMU.StreamSamples:output_type -> imu.v1.Sample
+	13, // 13: imu.v1.IMU.StreamEvents:output_type -> imu.v1.Event
+	14, // 14: imu.v1.IMU.GetCalibration:output_type -> imu.v1.Calibration
+	4,  // 15: imu.v1.IMU.GetConfig:output_type -> imu.v1.Config
+	4,  // 16: imu.v1.IMU.SetConfig:output_type -> imu.v1.Config
+	8,  // 17: imu.v1.IMU.SelfTest:output_type -> imu.v1.SelfTestReport
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_imu_v1_service_proto_init() }
+func file_imu_v1_service_proto_init() {
+	if File_imu_v1_service_proto != nil {
+		return
+	}
+	file_imu_v1_imu_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_imu_v1_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamSamplesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCalibrationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelfTestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SensorSelfTest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_imu_v1_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SelfTestReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_imu_v1_service_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_imu_v1_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_imu_v1_service_proto_goTypes,
+		DependencyIndexes: file_imu_v1_service_proto_depIdxs,
+		MessageInfos:      file_imu_v1_service_proto_msgTypes,
+	}.Build()
+	File_imu_v1_service_proto = out.File
+	file_imu_v1_service_proto_rawDesc = nil
+	file_imu_v1_service_proto_goTypes = nil
+	file_imu_v1_service_proto_depIdxs = nil
+}
@@ -0,0 +1,359 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: imu/v1/service.proto
+
+package imuv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	IMU_StreamSamples_FullMethodName  = "/imu.v1.IMU/StreamSamples"
+	IMU_StreamEvents_FullMethodName   = "/imu.v1.IMU/StreamEvents"
+	IMU_GetCalibration_FullMethodName = "/imu.v1.IMU/GetCalibration"
+	IMU_GetConfig_FullMethodName      = "/imu.v1.IMU/GetConfig"
+	IMU_SetConfig_FullMethodName      = "/imu.v1.IMU/SetConfig"
+	IMU_SelfTest_FullMethodName       = "/imu.v1.IMU/SelfTest"
+)
+
+// IMUClient is the client API for IMU service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IMUClient interface {
+	StreamSamples(ctx context.Context, in *StreamSamplesRequest, opts ...grpc.CallOption) (IMU_StreamSamplesClient, error)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (IMU_StreamEventsClient, error)
+	GetCalibration(ctx context.Context, in *GetCalibrationRequest, opts ...grpc.CallOption) (*Calibration, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Only the fields set are changed, the new config is returned. The sample
+	// period is fixed when the daemon starts, output data rates slower than it
+	// are rejected.
+	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// Samples stop flowing while the self-test runs, about 2 seconds. The device
+	// must be stationary.
+	SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestReport, error)
+}
+
+type iMUClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIMUClient(cc grpc.ClientConnInterface) IMUClient {
+	return &iMUClient{cc}
+}
+
+func (c *iMUClient) StreamSamples(ctx context.Context, in *StreamSamplesRequest, opts ...grpc.CallOption) (IMU_StreamSamplesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IMU_ServiceDesc.Streams[0], IMU_StreamSamples_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &iMUStreamSamplesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IMU_StreamSamplesClient interface {
+	Recv() (*Sample, error)
+	grpc.ClientStream
+}
+
+type iMUStreamSamplesClient struct {
+	grpc.ClientStream
+}
+
+func (x *iMUStreamSamplesClient) Recv() (*Sample, error) {
+	m := new(Sample)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iMUClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (IMU_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IMU_ServiceDesc.Streams[1], IMU_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &iMUStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IMU_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type iMUStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *iMUStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iMUClient) GetCalibration(ctx context.Context, in *GetCalibrationRequest, opts ...grpc.CallOption) (*Calibration, error) {
+	out := new(Calibration)
+	err := c.cc.Invoke(ctx, IMU_GetCalibration_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iMUClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, IMU_GetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iMUClient) SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, IMU_SetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iMUClient) SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestReport, error) {
+	out := new(SelfTestReport)
+	err := c.cc.Invoke(ctx, IMU_SelfTest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IMUServer is the server API for IMU service.
+// All implementations must embed UnimplementedIMUServer
+// for forward compatibility
+type IMUServer interface {
+	StreamSamples(*StreamSamplesRequest, IMU_StreamSamplesServer) error
+	StreamEvents(*StreamEventsRequest, IMU_StreamEventsServer) error
+	GetCalibration(context.Context, *GetCalibrationRequest) (*Calibration, error)
+	GetConfig(context.Context, *GetConfigRequest) (*Config, error)
+	// Only the fields set are changed, the new config is returned. The sample
+	// period is fixed when the daemon starts, output data rates slower than it
+	// are rejected.
+	SetConfig(context.Context, *SetConfigRequest) (*Config, error)
+	// Samples stop flowing while the self-test runs, about 2 seconds. The device
+	// must be stationary.
+	SelfTest(context.Context, *SelfTestRequest) (*SelfTestReport, error)
+	mustEmbedUnimplementedIMUServer()
+}
+
+// UnimplementedIMUServer must be embedded to have forward compatible implementations.
+type UnimplementedIMUServer struct {
+}
+
+func (UnimplementedIMUServer) StreamSamples(*StreamSamplesRequest, IMU_StreamSamplesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSamples not implemented")
+}
+func (UnimplementedIMUServer) StreamEvents(*StreamEventsRequest, IMU_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedIMUServer) GetCalibration(context.Context, *GetCalibrationRequest) (*Calibration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalibration not implemented")
+}
+func (UnimplementedIMUServer) GetConfig(context.Context, *GetConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedIMUServer) SetConfig(context.Context, *SetConfigRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfig not implemented")
+}
+func (UnimplementedIMUServer) SelfTest(context.Context, *SelfTestRequest) (*SelfTestReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (UnimplementedIMUServer) mustEmbedUnimplementedIMUServer() {}
+
+// UnsafeIMUServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IMUServer will
+// result in compilation errors.
+type UnsafeIMUServer interface {
+	mustEmbedUnimplementedIMUServer()
+}
+
+func RegisterIMUServer(s grpc.ServiceRegistrar, srv IMUServer) {
+	s.RegisterService(&IMU_ServiceDesc, srv)
+}
+
+func _IMU_StreamSamples_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSamplesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IMUServer).StreamSamples(m, &iMUStreamSamplesServer{stream})
+}
+
+type IMU_StreamSamplesServer interface {
+	Send(*Sample) error
+	grpc.ServerStream
+}
+
+type iMUStreamSamplesServer struct {
+	grpc.ServerStream
+}
+
+func (x *iMUStreamSamplesServer) Send(m *Sample) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IMU_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IMUServer).StreamEvents(m, &iMUStreamEventsServer{stream})
+}
+
+type IMU_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type iMUStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *iMUStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IMU_GetCalibration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalibrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IMUServer).GetCalibration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IMU_GetCalibration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IMUServer).GetCalibration(ctx, req.(*GetCalibrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IMU_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IMUServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IMU_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IMUServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IMU_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IMUServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IMU_SetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IMUServer).SetConfig(ctx, req.(*SetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IMU_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IMUServer).SelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IMU_SelfTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IMUServer).SelfTest(ctx, req.(*SelfTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IMU_ServiceDesc is the grpc.ServiceDesc for IMU service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IMU_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "imu.v1.IMU",
+	HandlerType: (*IMUServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCalibration",
+			Handler:    _IMU_GetCalibration_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _IMU_GetConfig_Handler,
+		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _IMU_SetConfig_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _IMU_SelfTest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSamples",
+			Handler:       _IMU_StreamSamples_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _IMU_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "imu/v1/service.proto",
+}
@@ -0,0 +1,116 @@
+package recording
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// A recording starts with a preamble: the magic, the format version
+// (uint16) and the length of the header (uint32), followed by the header as
+// JSON and then fixed size records until the end of the file. Integers are
+// little endian.
+//
+// Version 1 records are 22 bytes: the sample time in nanoseconds since the
+// unix epoch (int64), the raw acceleration X, Y, Z (int16), the raw angular
+// rate X, Y, Z (int16) and the raw temperature (int16), along the IMU axes.
+const (
+	Magic      = "IMUREC"
+	Version    = uint16(1)
+	RecordSize = 22
+
+	preambleSize = len(Magic) + 2 + 4
+	// Limits the allocation when reading a corrupted header length.
+	maxHeaderSize = 1 << 20
+)
+
+var byteOrder = binary.LittleEndian
+
+var ErrNotARecording = errors.New("not an IMU recording")
+
+// Field describes a record field in the header so that the file can be read
+// without this package.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Unit string `json:"unit"`
+}
+
+var recordFields = []Field{
+	{Name: "time", Type: "int64", Unit: "ns since unix epoch"},
+	{Name: "acceleration_x", Type: "int16", Unit: "lsb"},
+	{Name: "acceleration_y", Type: "int16", Unit: "lsb"},
+	{Name: "acceleration_z", Type: "int16", Unit: "lsb"},
+	{Name: "angular_rate_x", Type: "int16", Unit: "lsb"},
+	{Name: "angular_rate_y", Type: "int16", Unit: "lsb"},
+	{Name: "angular_rate_z", Type: "int16", Unit: "lsb"},
+	{Name: "temperature", Type: "int16", Unit: "lsb"},
+}
+
+// Header describes the device the samples were recorded with. Configuration
+// holds what is needed to convert the raw values, AxisMap and Mounting how to
+// bring them in the camera frame.
+type Header struct {
+	Created       time.Time               `json:"created"`
+	Device        string                  `json:"device"`
+	Configuration *iim42652.Configuration `json:"configuration"`
+	AxisMap       *iim42652.AxisMap       `json:"axis_map,omitempty"`
+	// Mounting rotation from the IMU to the camera frame, when known.
+	Mounting *iim42652.RotationMatrix `json:"mounting,omitempty"`
+	Metadata map[string]string        `json:"metadata,omitempty"`
+	// Set by the Writer.
+	RecordSize int     `json:"record_size"`
+	Fields     []Field `json:"fields"`
+}
+
+// Record holds the raw values of a sample as read from the device.
+type Record struct {
+	Time           time.Time
+	Acceleration   [3]int16
+	AngularRate    [3]int16
+	RawTemperature int16
+}
+
+func NewRecord(sample *iim42652.Sample) *Record {
+	return &Record{
+		Time:           sample.Time,
+		Acceleration:   [3]int16{sample.Acceleration.RawX, sample.Acceleration.RawY, sample.Acceleration.RawZ},
+		AngularRate:    [3]int16{sample.AngularRate.RawX, sample.AngularRate.RawY, sample.AngularRate.RawZ},
+		RawTemperature: sample.RawTemperature,
+	}
+}
+
+func (r *Record) encode(buffer []byte) {
+	byteOrder.PutUint64(buffer[0:], uint64(r.Time.UnixNano()))
+	for axis := 0; axis < 3; axis++ {
+		byteOrder.PutUint16(buffer[8+2*axis:], uint16(r.Acceleration[axis]))
+		byteOrder.PutUint16(buffer[14+2*axis:], uint16(r.AngularRate[axis]))
+	}
+	byteOrder.PutUint16(buffer[20:], uint16(r.RawTemperature))
+}
+
+func decodeRecord(buffer []byte) *Record {
+	r := &Record{
+		Time:           time.Unix(0, int64(byteOrder.Uint64(buffer[0:]))),
+		RawTemperature: int16(byteOrder.Uint16(buffer[20:])),
+	}
+	for axis := 0; axis < 3; axis++ {
+		r.Acceleration[axis] = int16(byteOrder.Uint16(buffer[8+2*axis:]))
+		r.AngularRate[axis] = int16(byteOrder.Uint16(buffer[14+2*axis:]))
+	}
+	return r
+}
+
+// Sample converts the record with the sensitivities of the header
+// configuration, giving the values the driver returned while recording.
+func (h *Header) Sample(r *Record) *iim42652.Sample {
+	return &iim42652.Sample{
+		Time:           r.Time,
+		Acceleration:   iim42652.NewAcceleration(r.Acceleration[0], r.Acceleration[1], r.Acceleration[2], h.Configuration.AccelerationSensitivity),
+		AngularRate:    iim42652.NewGyroscope(r.AngularRate[0], r.AngularRate[1], r.AngularRate[2], h.Configuration.GyroScale),
+		RawTemperature: r.RawTemperature,
+		Temperature:    iim42652.ConvertRawTemperature(r.RawTemperature),
+	}
+}
@@ -0,0 +1,98 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+type Reader struct {
+	reader  *bufio.Reader
+	header  *Header
+	version uint16
+	buffer  [RecordSize]byte
+}
+
+// NewReader reads the preamble and the header of a recording.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{reader: bufio.NewReader(r)}
+
+	preamble := make([]byte, preambleSize)
+	if _, err := io.ReadFull(reader.reader, preamble); err != nil {
+		return nil, fmt.Errorf("reading preamble: %w", err)
+	}
+	if string(preamble[:len(Magic)]) != Magic {
+		return nil, ErrNotARecording
+	}
+	reader.version = byteOrder.Uint16(preamble[len(Magic):])
+	if reader.version != Version {
+		return nil, fmt.Errorf("unsupported recording version %d, expected %d", reader.version, Version)
+	}
+
+	headerSize := byteOrder.Uint32(preamble[len(Magic)+2:])
+	if headerSize > maxHeaderSize {
+		return nil, fmt.Errorf("header size %d exceeds %d", headerSize, maxHeaderSize)
+	}
+	encodedHeader := make([]byte, headerSize)
+	if _, err := io.ReadFull(reader.reader, encodedHeader); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	reader.header = &Header{}
+	if err := json.Unmarshal(encodedHeader, reader.header); err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+	if reader.header.RecordSize != RecordSize {
+		return nil, fmt.Errorf("record size %d does not match version %d record size %d", reader.header.RecordSize, reader.version, RecordSize)
+	}
+	if reader.header.Configuration == nil {
+		return nil, fmt.Errorf("header has no configuration")
+	}
+	return reader, nil
+}
+
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+func (r *Reader) Version() uint16 {
+	return r.version
+}
+
+// Read returns the next record, io.EOF at the end of the recording and
+// io.ErrUnexpectedEOF when the last record is truncated, like after a power
+// loss while recording.
+func (r *Reader) Read() (*Record, error) {
+	if _, err := io.ReadFull(r.reader, r.buffer[:]); err != nil {
+		return nil, err
+	}
+	return decodeRecord(r.buffer[:]), nil
+}
+
+func (r *Reader) ReadSample() (*iim42652.Sample, error) {
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return r.header.Sample(record), nil
+}
+
+// ReadAll returns the records until the end of the recording. A truncated
+// last record is dropped.
+func (r *Reader) ReadAll() ([]*Record, error) {
+	var records []*Record
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
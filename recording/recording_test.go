@@ -0,0 +1,139 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHeader() *Header {
+	return &Header{
+		Created: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Device:  "/dev/spidev0.0",
+		Configuration: &iim42652.Configuration{
+			AccelerometerFullScale:  16,
+			AccelerometerODR:        1000,
+			GyroFullScale:           2000,
+			GyroODR:                 1000,
+			GyroOffsets:             [3]int16{12, -3, 2047},
+			AccelerometerOffsets:    [3]int16{-2048, 0, 7},
+			AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+			GyroScale:               iim42652.GyroScalesG2000,
+		},
+		AxisMap:  iim42652.DefaultAxisMap(),
+		Mounting: &iim42652.RotationMatrix{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		Metadata: map[string]string{"vehicle": "test"},
+	}
+}
+
+func Test_RoundTrip(t *testing.T) {
+	records := []*Record{
+		{Time: time.Unix(1685620800, 123456789), Acceleration: [3]int16{0, 2048, -2048}, AngularRate: [3]int16{1, -1, 0}, RawTemperature: 1000},
+		{Time: time.Unix(1685620800, 133456789), Acceleration: [3]int16{-32768, 32767, 1}, AngularRate: [3]int16{32767, -32768, -2}, RawTemperature: -32768},
+		{Time: time.Unix(0, 0), RawTemperature: 32767},
+	}
+
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer, testHeader())
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewReader(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, Version, reader.Version())
+
+	expectedHeader := testHeader()
+	expectedHeader.RecordSize = RecordSize
+	expectedHeader.Fields = recordFields
+	assert.Equal(t, expectedHeader, reader.Header())
+
+	for _, expected := range records {
+		record, err := reader.Read()
+		require.NoError(t, err)
+		assert.Equal(t, expected.Acceleration, record.Acceleration)
+		assert.Equal(t, expected.AngularRate, record.AngularRate)
+		assert.Equal(t, expected.RawTemperature, record.RawTemperature)
+		assert.True(t, expected.Time.Equal(record.Time))
+	}
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+
+	headerSize := int(binary.LittleEndian.Uint32(buffer.Bytes()[8:]))
+	assert.Equal(t, preambleSize+headerSize+len(records)*RecordSize, buffer.Len())
+}
+
+func Test_Sample(t *testing.T) {
+	sample := &iim42652.Sample{
+		Time:           time.Unix(10, 5),
+		Acceleration:   iim42652.NewAcceleration(100, -2048, 16000, iim42652.AccelerationSensitivityG16),
+		AngularRate:    iim42652.NewGyroscope(-7, 3, 32767, iim42652.GyroScalesG2000),
+		RawTemperature: 2345,
+		Temperature:    iim42652.ConvertRawTemperature(2345),
+	}
+
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer, testHeader())
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteSample(sample))
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewReader(buffer)
+	require.NoError(t, err)
+	read, err := reader.ReadSample()
+	require.NoError(t, err)
+
+	assert.True(t, sample.Time.Equal(read.Time))
+	read.Time = sample.Time
+	assert.Equal(t, sample, read)
+}
+
+func Test_ReaderErrors(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer, testHeader())
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(&Record{Time: time.Unix(1, 0)}))
+	require.NoError(t, writer.Write(&Record{Time: time.Unix(2, 0)}))
+	require.NoError(t, writer.Flush())
+	valid := buffer.Bytes()
+
+	t.Run("truncated record", func(t *testing.T) {
+		reader, err := NewReader(bytes.NewReader(valid[:len(valid)-5]))
+		require.NoError(t, err)
+		_, err = reader.Read()
+		require.NoError(t, err)
+		_, err = reader.Read()
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+		reader, err = NewReader(bytes.NewReader(valid[:len(valid)-5]))
+		require.NoError(t, err)
+		records, err := reader.ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("not a recording", func(t *testing.T) {
+		_, err := NewReader(bytes.NewReader([]byte("acceleration: {0 0 1}\n")))
+		assert.ErrorIs(t, err, ErrNotARecording)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		data := append([]byte{}, valid...)
+		binary.LittleEndian.PutUint16(data[len(Magic):], 2)
+		_, err := NewReader(bytes.NewReader(data))
+		assert.ErrorContains(t, err, "unsupported recording version 2")
+	})
+
+	t.Run("missing configuration", func(t *testing.T) {
+		_, err := NewWriter(&bytes.Buffer{}, &Header{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// Writer writes a recording. Records are buffered, call Flush before closing
+// the underlying writer.
+type Writer struct {
+	writer *bufio.Writer
+	buffer [RecordSize]byte
+}
+
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	if header.Configuration == nil {
+		return nil, fmt.Errorf("header configuration is required to convert the raw values")
+	}
+
+	h := *header
+	h.RecordSize = RecordSize
+	h.Fields = recordFields
+	encodedHeader, err := json.Marshal(&h)
+	if err != nil {
+		return nil, fmt.Errorf("encoding header: %w", err)
+	}
+
+	preamble := make([]byte, preambleSize)
+	copy(preamble, Magic)
+	byteOrder.PutUint16(preamble[len(Magic):], Version)
+	byteOrder.PutUint32(preamble[len(Magic)+2:], uint32(len(encodedHeader)))
+
+	writer := &Writer{writer: bufio.NewWriter(w)}
+	if _, err := writer.writer.Write(preamble); err != nil {
+		return nil, fmt.Errorf("writing preamble: %w", err)
+	}
+	if _, err := writer.writer.Write(encodedHeader); err != nil {
+		return nil, fmt.Errorf("writing header: %w", err)
+	}
+	return writer, nil
+}
+
+func (w *Writer) Write(record *Record) error {
+	record.encode(w.buffer[:])
+	_, err := w.writer.Write(w.buffer[:])
+	return err
+}
+
+func (w *Writer) WriteSample(sample *iim42652.Sample) error {
+	return w.Write(NewRecord(sample))
+}
+
+func (w *Writer) Flush() error {
+	return w.writer.Flush()
+}
+
+// Buffered returns the number of bytes written but not flushed yet.
+func (w *Writer) Buffered() int {
+	return w.writer.Buffered()
+}
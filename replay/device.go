@@ -0,0 +1,296 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+)
+
+type Config struct {
+	// Replay speed relative to the recording: 1 keeps the original timing, 10
+	// replays ten times faster. Ignored in step mode.
+	Speed float64
+	// In step mode the replay only moves forward when Step is called, and
+	// Stream sends the records as fast as they are consumed.
+	Step bool
+}
+
+func (c Config) Validate() error {
+	if !c.Step && c.Speed <= 0 {
+		return fmt.Errorf("speed must be positive, got %v", c.Speed)
+	}
+	return nil
+}
+
+// Device serves the samples of a recording through the driver API. Getters
+// return the values of the record current at the replay clock, which starts
+// at the first record on the first call. Once the recording is over they
+// return io.EOF.
+type Device struct {
+	config Config
+	reader *recording.Reader
+	closer io.Closer
+
+	lock    sync.Mutex
+	started bool
+	current *recording.Record
+	// Whether current was sent by Stream or returned by Step. A record only
+	// read by the getters is still sent by Stream.
+	served bool
+	next   *recording.Record
+	err    error
+
+	wallStart      time.Time
+	recordingStart time.Time
+
+	now   func() time.Time
+	after func(d time.Duration) <-chan time.Time
+}
+
+var _ iim42652.Device = (*Device)(nil)
+
+func NewDevice(reader *recording.Reader, config Config) (*Device, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Device{
+		config: config,
+		reader: reader,
+		now:    time.Now,
+		after:  time.After,
+	}, nil
+}
+
+// Open replays the recording at path, Close closes it.
+func Open(path string, config Config) (*Device, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", path, err)
+	}
+
+	reader, err := recording.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	device, err := NewDevice(reader, config)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	device.closer = file
+	return device, nil
+}
+
+func (d *Device) Close() error {
+	if d.closer == nil {
+		return nil
+	}
+	return d.closer.Close()
+}
+
+func (d *Device) Header() *recording.Header {
+	return d.reader.Header()
+}
+
+// ReadConfiguration returns the configuration the recording was made with.
+func (d *Device) ReadConfiguration() (*iim42652.Configuration, error) {
+	configuration := *d.reader.Header().Configuration
+	return &configuration, nil
+}
+
+// start reads the first two records, the current one and the one after.
+func (d *Device) start() error {
+	if d.started {
+		return nil
+	}
+	d.started = true
+	d.wallStart = d.now()
+
+	if d.current, d.err = d.read(); d.err != nil {
+		return d.err
+	}
+	d.recordingStart = d.current.Time
+	d.next, d.err = d.read()
+	return nil
+}
+
+func (d *Device) read() (*recording.Record, error) {
+	record, err := d.reader.Read()
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// A truncated last record, the recording was cut while writing it.
+		return nil, io.EOF
+	}
+	return record, err
+}
+
+// advance moves to the next record, err is kept to be returned once the
+// last record has been served.
+func (d *Device) advance() error {
+	if d.next == nil {
+		d.current = nil
+		return d.err
+	}
+	d.current = d.next
+	d.served = false
+	d.next, d.err = d.read()
+	return nil
+}
+
+// serveNext moves to the record Stream sends next: the one following the last
+// one served, or the current one when it was only read by the getters.
+func (d *Device) serveNext() error {
+	if err := d.start(); err != nil {
+		return err
+	}
+	if d.served {
+		if err := d.advance(); err != nil {
+			return err
+		}
+	}
+	if d.current == nil {
+		return d.err
+	}
+	d.served = true
+	return nil
+}
+
+// seek moves to the record current at the replay clock.
+func (d *Device) seek() (*recording.Record, error) {
+	if err := d.start(); err != nil {
+		return nil, err
+	}
+	if d.current == nil {
+		return nil, d.err
+	}
+	if d.config.Step {
+		return d.current, nil
+	}
+
+	clock := d.recordingStart.Add(time.Duration(float64(d.now().Sub(d.wallStart)) * d.config.Speed))
+	for d.next != nil && !d.next.Time.After(clock) {
+		if err := d.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if d.next == nil && clock.After(d.current.Time) {
+		d.current = nil
+		return nil, d.err
+	}
+	return d.current, nil
+}
+
+func (d *Device) currentSample() (*iim42652.Sample, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	record, err := d.seek()
+	if err != nil {
+		return nil, err
+	}
+	return d.reader.Header().Sample(record), nil
+}
+
+// Step moves to the next record in step mode and returns it.
+func (d *Device) Step() (*iim42652.Sample, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if !d.config.Step {
+		return nil, fmt.Errorf("not in step mode")
+	}
+	if !d.started {
+		if err := d.start(); err != nil {
+			return nil, err
+		}
+	} else if err := d.advance(); err != nil {
+		return nil, err
+	}
+	if d.current == nil {
+		return nil, d.err
+	}
+	d.served = true
+	return d.reader.Header().Sample(d.current), nil
+}
+
+func (d *Device) GetSample() (*iim42652.Sample, error) {
+	return d.currentSample()
+}
+
+func (d *Device) GetAcceleration() (*iim42652.Acceleration, error) {
+	sample, err := d.currentSample()
+	if err != nil {
+		return nil, err
+	}
+	return sample.Acceleration, nil
+}
+
+func (d *Device) GetGyroscopeData() (*iim42652.AngularRate, error) {
+	sample, err := d.currentSample()
+	if err != nil {
+		return nil, err
+	}
+	return sample.AngularRate, nil
+}
+
+func (d *Device) GetTemperature() (iim42652.Temperature, error) {
+	sample, err := d.currentSample()
+	if err != nil {
+		return nil, err
+	}
+	return iim42652.NewTemperature(sample.Temperature), nil
+}
+
+// Stream sends every remaining record of the recording at its recorded time
+// (scaled by Speed), or as fast as they are consumed in step mode. period is
+// ignored, the recording has its own. Unlike the driver, Stream blocks on a
+// slow consumer rather than dropping samples, so replays are deterministic.
+// It returns io.EOF at the end of the recording.
+func (d *Device) Stream(ctx context.Context, period time.Duration, samples chan<- *iim42652.Sample) error {
+	for {
+		sample, wait, err := d.nextStreamed()
+		if err != nil {
+			return err
+		}
+
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-d.after(wait):
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case samples <- sample:
+		}
+	}
+}
+
+// nextStreamed moves to the next record and returns how long to wait before
+// sending it.
+func (d *Device) nextStreamed() (*iim42652.Sample, time.Duration, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.serveNext(); err != nil {
+		return nil, 0, err
+	}
+
+	sample := d.reader.Header().Sample(d.current)
+	if d.config.Step {
+		return sample, 0, nil
+	}
+	due := d.wallStart.Add(time.Duration(float64(d.current.Time.Sub(d.recordingStart)) / d.config.Speed))
+	return sample, due.Sub(d.now()), nil
+}
@@ -0,0 +1,195 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Unix(1685620800, 0)
+
+// newDevice replays 5 records taken 10ms apart, record n has n as raw values.
+func newDevice(t *testing.T, config Config) (*Device, *fakeClock) {
+	buffer := &bytes.Buffer{}
+	writer, err := recording.NewWriter(buffer, &recording.Header{
+		Configuration: &iim42652.Configuration{
+			AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+			GyroScale:               iim42652.GyroScalesG2000,
+		},
+	})
+	require.NoError(t, err)
+	for n := int16(0); n < 5; n++ {
+		require.NoError(t, writer.Write(&recording.Record{
+			Time:           start.Add(time.Duration(n) * 10 * time.Millisecond),
+			Acceleration:   [3]int16{n, n, n},
+			AngularRate:    [3]int16{n, n, n},
+			RawTemperature: n,
+		}))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := recording.NewReader(buffer)
+	require.NoError(t, err)
+	device, err := NewDevice(reader, config)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	device.now = clock.Now
+	device.after = clock.After
+	return device, clock
+}
+
+type fakeClock struct {
+	now    time.Time
+	waited []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waited = append(c.waited, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func Test_Getters(t *testing.T) {
+	tests := []struct {
+		name     string
+		speed    float64
+		elapsed  []time.Duration
+		expected []int16
+	}{
+		{"original timing", 1, []time.Duration{0, 5 * time.Millisecond, 10 * time.Millisecond, 15 * time.Millisecond, 31 * time.Millisecond}, []int16{0, 0, 1, 1, 3}},
+		{"faster than real time", 10, []time.Duration{0, time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond}, []int16{0, 1, 3, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device, clock := newDevice(t, Config{Speed: test.speed})
+			origin := clock.now
+
+			for i, elapsed := range test.elapsed {
+				clock.now = origin.Add(elapsed)
+				acceleration, err := device.GetAcceleration()
+				require.NoError(t, err)
+				assert.Equal(t, test.expected[i], acceleration.RawX, "at %s", elapsed)
+
+				angularRate, err := device.GetGyroscopeData()
+				require.NoError(t, err)
+				assert.Equal(t, test.expected[i], angularRate.RawZ)
+			}
+
+			clock.now = origin.Add(time.Second)
+			_, err := device.GetTemperature()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func Test_Step(t *testing.T) {
+	device, _ := newDevice(t, Config{Step: true})
+
+	sample, err := device.GetSample()
+	require.NoError(t, err)
+	assert.Equal(t, int16(0), sample.RawTemperature)
+
+	for n := int16(1); n < 5; n++ {
+		sample, err := device.Step()
+		require.NoError(t, err)
+		assert.Equal(t, n, sample.Acceleration.RawY)
+
+		temperature, err := device.GetTemperature()
+		require.NoError(t, err)
+		assert.Equal(t, iim42652.ConvertRawTemperature(n), *temperature)
+	}
+
+	_, err = device.Step()
+	assert.Equal(t, io.EOF, err)
+	_, err = device.GetSample()
+	assert.Equal(t, io.EOF, err)
+}
+
+func Test_Stream(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         Config
+		expectedWaited []time.Duration
+	}{
+		{"original timing", Config{Speed: 1}, []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}},
+		{"faster than real time", Config{Speed: 5}, []time.Duration{2 * time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond}},
+		{"step", Config{Step: true}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device, clock := newDevice(t, test.config)
+
+			samples := make(chan *iim42652.Sample)
+			done := make(chan error)
+			go func() {
+				done <- device.Stream(context.Background(), time.Second, samples)
+			}()
+
+			for n := int16(0); n < 5; n++ {
+				sample := <-samples
+				assert.Equal(t, n, sample.AngularRate.RawX)
+				assert.Equal(t, start.Add(time.Duration(n)*10*time.Millisecond), sample.Time)
+			}
+			assert.Equal(t, io.EOF, <-done)
+			assert.Equal(t, test.expectedWaited, clock.waited)
+		})
+	}
+}
+
+func Test_GetSampleThenStream(t *testing.T) {
+	for _, config := range []Config{{Speed: 1}, {Step: true}} {
+		device, _ := newDevice(t, config)
+
+		sample, err := device.GetSample()
+		require.NoError(t, err)
+		assert.Equal(t, int16(0), sample.RawTemperature)
+
+		// The record read by GetSample is streamed as well.
+		samples := make(chan *iim42652.Sample, 5)
+		assert.Equal(t, io.EOF, device.Stream(context.Background(), time.Second, samples))
+		require.Len(t, samples, 5)
+		for n := int16(0); n < 5; n++ {
+			assert.Equal(t, n, (<-samples).RawTemperature)
+		}
+	}
+}
+
+func Test_StepThenStream(t *testing.T) {
+	device, _ := newDevice(t, Config{Step: true})
+	_, err := device.Step()
+	require.NoError(t, err)
+
+	samples := make(chan *iim42652.Sample, 5)
+	assert.Equal(t, io.EOF, device.Stream(context.Background(), time.Second, samples))
+	require.Len(t, samples, 4)
+	assert.Equal(t, int16(1), (<-samples).RawTemperature)
+}
+
+func Test_StreamCancel(t *testing.T) {
+	device, _ := newDevice(t, Config{Step: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, device.Stream(ctx, time.Second, make(chan *iim42652.Sample)))
+}
+
+func Test_ConfigValidation(t *testing.T) {
+	assert.Error(t, Config{}.Validate())
+	assert.NoError(t, Config{Step: true}.Validate())
+}
@@ -0,0 +1,80 @@
+// Package replaytest provides a replay device for the tests of the hub
+// consumers.
+package replaytest
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/recording"
+	"github.com/streamingfast/imu-controller/replay"
+	"github.com/stretchr/testify/require"
+)
+
+// Start is the time of the first record.
+var Start = time.Unix(1685620800, 0).UTC()
+
+const (
+	// Records is the number of records, taken 10ms apart.
+	Records = 101
+	// ImpactRecord is the record holding a 5g impact, the others measure 1g
+	// along the Y axis.
+	ImpactRecord = 80
+)
+
+// GatedDevice replays the records in step mode once Start is called, so that
+// consumers can subscribe before the first sample. Record n has an angular
+// rate of n raw units around the Z axis.
+type GatedDevice struct {
+	*replay.Device
+	start     chan struct{}
+	startOnce sync.Once
+}
+
+func NewGatedDevice(t testing.TB) *GatedDevice {
+	buffer := &bytes.Buffer{}
+	writer, err := recording.NewWriter(buffer, &recording.Header{
+		Configuration: &iim42652.Configuration{
+			AccelerometerODR:        100,
+			AccelerationSensitivity: iim42652.AccelerationSensitivityG16,
+			GyroScale:               iim42652.GyroScalesG2000,
+		},
+	})
+	require.NoError(t, err)
+	for n := 0; n < Records; n++ {
+		record := &recording.Record{
+			Time:         Start.Add(time.Duration(n) * 10 * time.Millisecond),
+			Acceleration: [3]int16{0, 2048, 0},
+			AngularRate:  [3]int16{0, 0, int16(n)},
+		}
+		if n == ImpactRecord {
+			record.Acceleration = [3]int16{0, 10240, 0}
+		}
+		require.NoError(t, writer.Write(record))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := recording.NewReader(buffer)
+	require.NoError(t, err)
+	device, err := replay.NewDevice(reader, replay.Config{Step: true})
+	require.NoError(t, err)
+	return &GatedDevice{Device: device, start: make(chan struct{})}
+}
+
+// Start lets Stream send the records, it can be called more than once.
+func (d *GatedDevice) Start() {
+	d.startOnce.Do(func() { close(d.start) })
+}
+
+func (d *GatedDevice) Stream(ctx context.Context, period time.Duration, samples chan<- *iim42652.Sample) error {
+	select {
+	case <-d.start:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return d.Device.Stream(ctx, period, samples)
+}
@@ -0,0 +1,132 @@
+package shm
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// A ring buffer file starts with a 64 bytes header followed by Capacity
+// slots of RecordSize bytes.
+//
+// The header holds the magic, the format version (uint32), the record size
+// (uint32), the capacity (uint32), a closed flag (uint32) set when the
+// publisher stops, and the number of records published (uint64, offset 24).
+//
+// A slot starts with the sequence number of its record plus one (uint64), 0
+// while the record is written, followed by the sample time in nanoseconds
+// since the unix epoch (int64), the raw acceleration X, Y, Z, angular rate X,
+// Y, Z and temperature (int16, then 2 bytes of padding), the acceleration in
+// g, the angular rate in dps and the temperature in °C (float64), along the
+// IMU axes.
+//
+// Integers and floats are little endian, every 64 bits word is read and
+// written atomically so the file is only portable between little endian hosts.
+const (
+	Magic      = "IMUSHM\x00\x00"
+	Version    = uint32(1)
+	HeaderSize = 64
+	RecordSize = 88
+
+	versionOffset    = 8
+	recordSizeOffset = 12
+	capacityOffset   = 16
+	closedOffset     = 20
+	publishedOffset  = 24
+
+	timeOffset   = 8
+	rawOffset    = 16
+	scaledOffset = 32
+)
+
+var byteOrder = binary.LittleEndian
+
+var ErrNotARingBuffer = errors.New("not an IMU ring buffer")
+
+func fileSize(capacity int) int {
+	return HeaderSize + capacity*RecordSize
+}
+
+// word returns the 64 bits word of data at offset, which must be aligned.
+func word(data []byte, offset int) *uint64 {
+	return (*uint64)(unsafe.Pointer(&data[offset]))
+}
+
+func flag(data []byte, offset int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&data[offset]))
+}
+
+func loadWord(data []byte, offset int) uint64 {
+	return atomic.LoadUint64(word(data, offset))
+}
+
+func storeWord(data []byte, offset int, value uint64) {
+	atomic.StoreUint64(word(data, offset), value)
+}
+
+// The payload of a slot, after its sequence number, is written and read one
+// atomic 64 bits word at a time: with plain accesses, weakly ordered CPUs like
+// arm64 may let a reader see words of the next record before the sequence
+// number change that tells it to discard them.
+const payloadWords = (RecordSize - timeOffset) / 8
+
+func encodeSample(slot []byte, sample *iim42652.Sample) {
+	var payload [payloadWords]uint64
+	payload[0] = uint64(sample.Time.UnixNano())
+	raw := [8]int16{
+		sample.Acceleration.RawX, sample.Acceleration.RawY, sample.Acceleration.RawZ,
+		sample.AngularRate.RawX, sample.AngularRate.RawY, sample.AngularRate.RawZ,
+		sample.RawTemperature,
+	}
+	for n, value := range raw {
+		payload[1+n/4] |= uint64(uint16(value)) << (16 * (n % 4))
+	}
+	scaled := [7]float64{
+		sample.Acceleration.X, sample.Acceleration.Y, sample.Acceleration.Z,
+		sample.AngularRate.X, sample.AngularRate.Y, sample.AngularRate.Z,
+		sample.Temperature,
+	}
+	for n, value := range scaled {
+		payload[3+n] = math.Float64bits(value)
+	}
+
+	for n, value := range payload {
+		storeWord(slot, timeOffset+8*n, value)
+	}
+}
+
+func decodeSample(slot []byte) *iim42652.Sample {
+	var payload [payloadWords]uint64
+	for n := range payload {
+		payload[n] = loadWord(slot, timeOffset+8*n)
+	}
+
+	var raw [7]int16
+	for n := range raw {
+		raw[n] = int16(payload[1+n/4] >> (16 * (n % 4)))
+	}
+	var scaled [7]float64
+	for n := range scaled {
+		scaled[n] = math.Float64frombits(payload[3+n])
+	}
+
+	return &iim42652.Sample{
+		Time: time.Unix(0, int64(payload[0])),
+		Acceleration: &iim42652.Acceleration{
+			RawX: raw[0], RawY: raw[1], RawZ: raw[2],
+			X: scaled[0], Y: scaled[1], Z: scaled[2],
+			TotalMagnitude: math.Sqrt(scaled[0]*scaled[0] + scaled[1]*scaled[1] + scaled[2]*scaled[2]),
+		},
+		AngularRate: &iim42652.AngularRate{
+			RawX: raw[3], RawY: raw[4], RawZ: raw[5],
+			X: scaled[3], Y: scaled[4], Z: scaled[5],
+		},
+		RawTemperature: raw[6],
+		Temperature:    scaled[6],
+	}
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package shm
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func mmap(file *os.File, size int, writable bool) ([]byte, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, size, prot, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("mapping %q: %w", file.Name(), err)
+	}
+	return data, nil
+}
+
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
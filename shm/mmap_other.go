@@ -0,0 +1,18 @@
+//go:build !linux
+
+package shm
+
+import (
+	"errors"
+	"os"
+)
+
+var errUnsupported = errors.New("shared memory ring buffers are only supported on linux")
+
+func mmap(file *os.File, size int, writable bool) ([]byte, error) {
+	return nil, errUnsupported
+}
+
+func munmap(data []byte) error {
+	return errUnsupported
+}
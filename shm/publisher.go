@@ -0,0 +1,117 @@
+package shm
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// Publisher writes samples to a ring buffer file, usually in /dev/shm, that
+// any number of Reader map to read them without a copy through the kernel.
+// There must be a single Publisher per file, it is not safe for concurrent
+// use.
+type Publisher struct {
+	file      *os.File
+	data      []byte
+	capacity  uint64
+	published uint64
+}
+
+// Create creates the ring buffer file at path holding the last capacity
+// samples. An existing file is replaced: its readers see it closed and must
+// open the new one.
+func Create(path string, capacity int) (*Publisher, error) {
+	if capacity < 2 {
+		return nil, fmt.Errorf("capacity must be at least 2, got %d", capacity)
+	}
+
+	// The file is ready before it is renamed, so readers never see it
+	// partially initialized.
+	temporary := path + ".tmp"
+	file, err := os.OpenFile(temporary, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("creating ring buffer: %w", err)
+	}
+	if err := file.Truncate(int64(fileSize(capacity))); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("sizing ring buffer: %w", err)
+	}
+	data, err := mmap(file, fileSize(capacity), true)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	copy(data, Magic)
+	byteOrder.PutUint32(data[versionOffset:], Version)
+	byteOrder.PutUint32(data[recordSizeOffset:], RecordSize)
+	byteOrder.PutUint32(data[capacityOffset:], uint32(capacity))
+
+	closeExisting(path)
+	if err := os.Rename(temporary, path); err != nil {
+		munmap(data)
+		file.Close()
+		return nil, fmt.Errorf("renaming ring buffer: %w", err)
+	}
+
+	return &Publisher{
+		file:     file,
+		data:     data,
+		capacity: uint64(capacity),
+	}, nil
+}
+
+// closeExisting flags the ring buffer being replaced as closed, so that its
+// readers stop waiting for samples.
+func closeExisting(path string) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.Size() < HeaderSize {
+		return
+	}
+	data, err := mmap(file, HeaderSize, true)
+	if err != nil {
+		return
+	}
+	defer munmap(data)
+	if string(data[:len(Magic)]) == Magic {
+		atomic.StoreUint32(flag(data, closedOffset), 1)
+	}
+}
+
+// Publish writes sample to the next slot, overwriting the oldest sample.
+func (p *Publisher) Publish(sample *iim42652.Sample) {
+	offset := HeaderSize + int(p.published%p.capacity)*RecordSize
+	slot := p.data[offset : offset+RecordSize]
+
+	// Readers compare the slot sequence before and after copying the slot,
+	// clearing it first lets them detect a record overwritten meanwhile.
+	storeWord(slot, 0, 0)
+	encodeSample(slot, sample)
+	storeWord(slot, 0, p.published+1)
+
+	p.published++
+	storeWord(p.data, publishedOffset, p.published)
+}
+
+// Published returns the number of samples published since Create.
+func (p *Publisher) Published() uint64 {
+	return p.published
+}
+
+// Close flags the ring buffer as closed for its readers and unmaps it, the
+// file is left in place.
+func (p *Publisher) Close() error {
+	atomic.StoreUint32(flag(p.data, closedOffset), 1)
+	if err := munmap(p.data); err != nil {
+		p.file.Close()
+		return fmt.Errorf("unmapping ring buffer: %w", err)
+	}
+	return p.file.Close()
+}
@@ -0,0 +1,177 @@
+package shm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+// OverrunError is returned when the publisher overwrote samples before they
+// were read. The reader resumes from the oldest sample still available.
+type OverrunError struct {
+	Missed uint64
+}
+
+func (e *OverrunError) Error() string {
+	return fmt.Sprintf("ring buffer overrun, %d samples missed", e.Missed)
+}
+
+// Reader reads the samples of a ring buffer file written by a Publisher, in
+// order. It is not safe for concurrent use, open a Reader per goroutine.
+type Reader struct {
+	file     *os.File
+	data     []byte
+	capacity uint64
+	// Sequence number of the next sample to read.
+	next uint64
+}
+
+// Open maps the ring buffer file at path, the first sample read is the next
+// one published.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening ring buffer: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading ring buffer size: %w", err)
+	}
+	if info.Size() < HeaderSize {
+		file.Close()
+		return nil, ErrNotARingBuffer
+	}
+
+	header := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading ring buffer header: %w", err)
+	}
+	if string(header[:len(Magic)]) != Magic {
+		file.Close()
+		return nil, ErrNotARingBuffer
+	}
+	if version := byteOrder.Uint32(header[versionOffset:]); version != Version {
+		file.Close()
+		return nil, fmt.Errorf("unsupported ring buffer version %d", version)
+	}
+	if recordSize := byteOrder.Uint32(header[recordSizeOffset:]); recordSize != RecordSize {
+		file.Close()
+		return nil, fmt.Errorf("unsupported record size %d", recordSize)
+	}
+	capacity := int(byteOrder.Uint32(header[capacityOffset:]))
+	if capacity < 2 || info.Size() < int64(fileSize(capacity)) {
+		file.Close()
+		return nil, fmt.Errorf("ring buffer truncated, %d bytes for a capacity of %d", info.Size(), capacity)
+	}
+
+	data, err := mmap(file, fileSize(capacity), false)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Reader{
+		file:     file,
+		data:     data,
+		capacity: uint64(capacity),
+		next:     loadWord(data, publishedOffset),
+	}, nil
+}
+
+// TryNext returns the next sample, or false when it is not published yet. It
+// returns an *OverrunError when samples were missed and io.EOF once the
+// publisher is closed and all its samples were read.
+func (r *Reader) TryNext() (*iim42652.Sample, bool, error) {
+	published := loadWord(r.data, publishedOffset)
+	if r.next >= published {
+		if atomic.LoadUint32(flag(r.data, closedOffset)) != 0 && r.next == loadWord(r.data, publishedOffset) {
+			return nil, false, io.EOF
+		}
+		return nil, false, nil
+	}
+	if err := r.checkOverrun(published); err != nil {
+		return nil, false, err
+	}
+
+	offset := HeaderSize + int(r.next%r.capacity)*RecordSize
+	slot := r.data[offset : offset+RecordSize]
+	if loadWord(slot, 0) != r.next+1 {
+		return nil, false, r.checkOverrun(loadWord(r.data, publishedOffset))
+	}
+	sample := decodeSample(slot)
+	if loadWord(slot, 0) != r.next+1 {
+		return nil, false, r.checkOverrun(loadWord(r.data, publishedOffset))
+	}
+
+	r.next++
+	return sample, true, nil
+}
+
+// checkOverrun moves to the oldest sample still available when the next one
+// was overwritten. The slot after the last sample published may be being
+// written, so capacity - 1 samples are available.
+func (r *Reader) checkOverrun(published uint64) error {
+	if published < r.capacity {
+		return nil
+	}
+	oldest := published - r.capacity + 1
+	if r.next >= oldest {
+		return nil
+	}
+	missed := oldest - r.next
+	r.next = oldest
+	return &OverrunError{Missed: missed}
+}
+
+// Next waits for the next sample, checking every pollInterval, until ctx is
+// done. See TryNext for the errors.
+func (r *Reader) Next(ctx context.Context, pollInterval time.Duration) (*iim42652.Sample, error) {
+	for {
+		sample, ok, err := r.TryNext()
+		if ok || err != nil {
+			return sample, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Latest skips to the last sample published and returns it, or false when
+// none was. The samples skipped are not reported as an overrun.
+func (r *Reader) Latest() (*iim42652.Sample, bool, error) {
+	for {
+		published := loadWord(r.data, publishedOffset)
+		if published == 0 {
+			return nil, false, nil
+		}
+		r.next = published - 1
+		sample, ok, err := r.TryNext()
+		if _, overrun := err.(*OverrunError); overrun {
+			continue
+		}
+		return sample, ok, err
+	}
+}
+
+// Sequence returns the sequence number of the next sample to read, the
+// number of samples published before it.
+func (r *Reader) Sequence() uint64 {
+	return r.next
+}
+
+func (r *Reader) Close() error {
+	if err := munmap(r.data); err != nil {
+		r.file.Close()
+		return fmt.Errorf("unmapping ring buffer: %w", err)
+	}
+	return r.file.Close()
+}
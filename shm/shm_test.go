@@ -0,0 +1,220 @@
+//go:build linux
+
+package shm
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sample(n int) *iim42652.Sample {
+	raw := int16(n)
+	return &iim42652.Sample{
+		Time:           time.Unix(0, int64(n)*int64(10*time.Millisecond)),
+		Acceleration:   iim42652.NewAcceleration(raw, -raw, 2048, iim42652.AccelerationSensitivityG16),
+		AngularRate:    iim42652.NewGyroscope(-raw, raw, 1, iim42652.GyroScalesG2000),
+		RawTemperature: raw,
+		Temperature:    iim42652.ConvertRawTemperature(raw),
+	}
+}
+
+func Test_PublishRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imu")
+	publisher, err := Create(path, 8)
+	require.NoError(t, err)
+
+	reader, err := Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	_, ok, err := reader.TryNext()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for n := 0; n < 5; n++ {
+		publisher.Publish(sample(n))
+	}
+	for n := 0; n < 5; n++ {
+		read, ok, err := reader.TryNext()
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, sample(n), read)
+	}
+	_, ok, err = reader.TryNext()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 7 of the 8 slots can be read, the oldest samples are skipped.
+	for n := 5; n < 25; n++ {
+		publisher.Publish(sample(n))
+	}
+	_, _, err = reader.TryNext()
+	assert.Equal(t, &OverrunError{Missed: 13}, err)
+	for n := 18; n < 25; n++ {
+		read, ok, err := reader.TryNext()
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, sample(n), read)
+	}
+
+	publisher.Publish(sample(25))
+	publisher.Publish(sample(26))
+	latest, ok, err := reader.Latest()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, sample(26), latest)
+	assert.Equal(t, uint64(27), reader.Sequence())
+
+	require.NoError(t, publisher.Close())
+	_, _, err = reader.TryNext()
+	assert.Equal(t, io.EOF, err)
+}
+
+func Test_Replace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imu")
+	publisher, err := Create(path, 8)
+	require.NoError(t, err)
+	reader, err := Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	publisher.Publish(sample(0))
+
+	// The samples published before the publisher was replaced are read first.
+	replacement, err := Create(path, 16)
+	require.NoError(t, err)
+	defer replacement.Close()
+	read, ok, err := reader.TryNext()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, sample(0), read)
+	_, _, err = reader.TryNext()
+	assert.Equal(t, io.EOF, err)
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+	replacement.Publish(sample(1))
+	read, ok, err = reopened.TryNext()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, sample(1), read)
+}
+
+func Test_OpenInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imu")
+	require.NoError(t, os.WriteFile(path, make([]byte, 128), 0644))
+	_, err := Open(path)
+	assert.ErrorIs(t, err, ErrNotARingBuffer)
+
+	_, err = Create(path, 1)
+	assert.Error(t, err)
+}
+
+func Test_ConcurrentReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imu")
+	publisher, err := Create(path, 16)
+	require.NoError(t, err)
+	reader, err := Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	const count = 100000
+	go func() {
+		for n := 0; n < count; n++ {
+			publisher.Publish(sample(n))
+		}
+		publisher.Close()
+	}()
+
+	// Every sample is either read whole, or reported missed.
+	read, missed := uint64(0), uint64(0)
+	for {
+		next := reader.Sequence()
+		s, ok, err := reader.TryNext()
+		if err == io.EOF {
+			break
+		}
+		if overrun, isOverrun := err.(*OverrunError); isOverrun {
+			missed += overrun.Missed
+			continue
+		}
+		require.NoError(t, err)
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		require.Equal(t, sample(int(next)), s)
+		read++
+	}
+	assert.Equal(t, uint64(count), read+missed)
+	assert.NotZero(t, read)
+}
+
+// Test_TornSamples reads a two slots ring, overwritten as fast as possible,
+// from several readers: a sample read must never mix the words of two records.
+func Test_TornSamples(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imu")
+	publisher, err := Create(path, 2)
+	require.NoError(t, err)
+
+	const count = 200000
+	var read, inconsistent atomic.Uint64
+	var published atomic.Bool
+	var readers sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		reader, err := Open(path)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		readers.Add(1)
+		go func(latest bool) {
+			defer readers.Done()
+			for {
+				var s *iim42652.Sample
+				var ok bool
+				var err error
+				if latest {
+					s, ok, err = reader.Latest()
+				} else {
+					s, ok, err = reader.TryNext()
+				}
+				if err == io.EOF {
+					return
+				}
+				if !ok {
+					continue
+				}
+				read.Add(1)
+				// The time tells which record was read, the raw and scaled values
+				// must all come from it.
+				if !reflect.DeepEqual(sample(int(s.Time.UnixNano()/int64(10*time.Millisecond))), s) {
+					inconsistent.Add(1)
+				}
+				if latest && published.Load() {
+					return
+				}
+			}
+		}(n%2 == 0)
+	}
+
+	for n := 0; n < count; n++ {
+		publisher.Publish(sample(n))
+	}
+	published.Store(true)
+	require.NoError(t, publisher.Close())
+	readers.Wait()
+
+	assert.NotZero(t, read.Load())
+	assert.Zero(t, inconsistent.Load())
+}
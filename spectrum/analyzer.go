@@ -0,0 +1,315 @@
+package spectrum
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+)
+
+type Band struct {
+	Low  float64 `json:"low_hz"`
+	High float64 `json:"high_hz"`
+}
+
+func (b Band) String() string {
+	return fmt.Sprintf("%g-%gHz", b.Low, b.High)
+}
+
+// DefaultBands split the spectrum in the ranges of the vehicle body, the
+// suspension and wheels, the engine, and the mount resonances. Bands above
+// the nyquist frequency are left out of the spectrum.
+func DefaultBands() []Band {
+	return []Band{{1, 5}, {5, 20}, {20, 50}, {50, 100}, {100, 200}, {200, 500}}
+}
+
+type Config struct {
+	// Rate the accelerometer is sampled at, in Hz. It must match the ODR
+	// configured on the device.
+	SampleRate float64
+	// Number of samples per FFT, a power of two. The frequency resolution is
+	// SampleRate / WindowSize.
+	WindowSize int
+	// Fraction (0 to 0.9) of a window shared with the previous one.
+	Overlap float64
+	// Number of windows averaged (Welch's method) in a reported spectrum.
+	Averages int
+	// Number of dominant frequencies reported per axis.
+	Peaks int
+	// Peaks below this frequency (Hz) are ignored, slow drifts leak there.
+	MinFrequency float64
+	Bands        []Band
+}
+
+func DefaultConfig(sampleRate float64) *Config {
+	return &Config{
+		SampleRate:   sampleRate,
+		WindowSize:   1024,
+		Overlap:      0.5,
+		Averages:     8,
+		Peaks:        3,
+		MinFrequency: 1,
+		Bands:        DefaultBands(),
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %v", c.SampleRate)
+	}
+	if c.WindowSize < 16 || bits.OnesCount(uint(c.WindowSize)) != 1 {
+		return fmt.Errorf("window size must be a power of two of at least 16, got %d", c.WindowSize)
+	}
+	if c.Overlap < 0 || c.Overlap > 0.9 {
+		return fmt.Errorf("overlap must be between 0 and 0.9, got %v", c.Overlap)
+	}
+	if c.Averages < 1 {
+		return fmt.Errorf("averages must be at least 1, got %d", c.Averages)
+	}
+	if c.Peaks < 0 {
+		return fmt.Errorf("peaks must not be negative, got %d", c.Peaks)
+	}
+	for _, band := range c.Bands {
+		if band.Low < 0 || band.High <= band.Low {
+			return fmt.Errorf("invalid band %s", band)
+		}
+	}
+	return nil
+}
+
+type Peak struct {
+	Frequency float64 `json:"frequency_hz"`
+	// Power spectral density at the peak, in g²/Hz.
+	PSD float64 `json:"psd"`
+}
+
+type BandEnergy struct {
+	Band
+	// Mean square acceleration in the band, in g².
+	Energy float64 `json:"energy"`
+	RMS    float64 `json:"rms"`
+}
+
+// Spectrum is the power spectral density of the acceleration along the camera
+// axes (X forward, Y left, Z up) averaged over Segments windows. The mean of
+// every window is removed, gravity and static offsets do not show. Windows
+// with a gap in the samples are left out and counted in Discarded.
+type Spectrum struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	SampleRate float64   `json:"sample_rate_hz"`
+	// Distance between two PSD bins, in Hz.
+	Resolution float64 `json:"resolution_hz"`
+	Segments   int     `json:"segments"`
+	Discarded  int     `json:"discarded_segments"`
+	// One-sided PSD in g²/Hz, bin k is at k * Resolution.
+	PSD   [3][]float64    `json:"psd"`
+	RMS   [3]float64      `json:"rms"`
+	Peaks [3][]Peak       `json:"peaks"`
+	Bands [3][]BandEnergy `json:"bands"`
+}
+
+func (s *Spectrum) Frequency(bin int) float64 {
+	return float64(bin) * s.Resolution
+}
+
+// Analyzer computes the acceleration spectrum of a stream of camera samples.
+type Analyzer struct {
+	config *Config
+	window []float64
+	// Sum of squares of the window, used to scale the PSD.
+	windowPower float64
+	hop         int
+
+	// Ring of the last WindowSize samples, oldest is the one at next.
+	samples [3][]float64
+	times   []time.Time
+	next    int
+	pending int
+
+	sum       [3][]float64
+	segments  int
+	discarded int
+	start     time.Time
+	buffer    []complex128
+}
+
+func NewAnalyzer(config *Config) (*Analyzer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	a := &Analyzer{
+		config: config,
+		window: hann(config.WindowSize),
+		hop:    int(math.Max(1, math.Round(float64(config.WindowSize)*(1-config.Overlap)))),
+		times:  make([]time.Time, config.WindowSize),
+		buffer: make([]complex128, config.WindowSize),
+	}
+	for _, w := range a.window {
+		a.windowPower += w * w
+	}
+	for axis := 0; axis < 3; axis++ {
+		a.samples[axis] = make([]float64, config.WindowSize)
+		a.sum[axis] = make([]float64, config.WindowSize/2+1)
+	}
+	a.pending = config.WindowSize
+	return a, nil
+}
+
+// Process adds a sample and returns a spectrum once Averages windows were
+// analyzed, nil otherwise.
+func (a *Analyzer) Process(sample *iim42652.CameraSample) *Spectrum {
+	for axis := 0; axis < 3; axis++ {
+		a.samples[axis][a.next] = sample.Acceleration[axis]
+	}
+	a.times[a.next] = sample.Time
+	a.next = (a.next + 1) % a.config.WindowSize
+
+	a.pending--
+	if a.pending > 0 {
+		return nil
+	}
+	a.pending = a.hop
+
+	if !a.contiguous() {
+		a.discarded++
+		return nil
+	}
+	if a.segments == 0 {
+		a.start = a.times[a.next]
+	}
+	for axis := 0; axis < 3; axis++ {
+		a.accumulate(axis)
+	}
+	a.segments++
+	if a.segments < a.config.Averages {
+		return nil
+	}
+
+	spectrum := a.spectrum(sample.Time)
+	a.segments = 0
+	a.discarded = 0
+	for axis := 0; axis < 3; axis++ {
+		for k := range a.sum[axis] {
+			a.sum[axis][k] = 0
+		}
+	}
+	return spectrum
+}
+
+// contiguous reports whether the samples of the window are evenly spaced. The
+// PSD assumes SampleRate, a period skipped by the reader or a dropped sample
+// would shift the spectrum.
+func (a *Analyzer) contiguous() bool {
+	maxGap := time.Duration(1.5 * float64(time.Second) / a.config.SampleRate)
+	n := len(a.times)
+	previous := a.times[a.next]
+	for i := 1; i < n; i++ {
+		current := a.times[(a.next+i)%n]
+		if current.Sub(previous) > maxGap {
+			return false
+		}
+		previous = current
+	}
+	return true
+}
+
+func (a *Analyzer) accumulate(axis int) {
+	samples := a.samples[axis]
+	mean := 0.0
+	for _, x := range samples {
+		mean += x
+	}
+	mean /= float64(len(samples))
+
+	// The window is full whenever it is analyzed, it starts at the oldest sample.
+	for i := range a.buffer {
+		x := samples[(a.next+i)%len(samples)]
+		a.buffer[i] = complex((x-mean)*a.window[i], 0)
+	}
+	fft(a.buffer)
+
+	for k := range a.sum[axis] {
+		re, im := real(a.buffer[k]), imag(a.buffer[k])
+		a.sum[axis][k] += re*re + im*im
+	}
+}
+
+func (a *Analyzer) spectrum(end time.Time) *Spectrum {
+	n := a.config.WindowSize
+	s := &Spectrum{
+		Start:      a.start,
+		End:        end,
+		SampleRate: a.config.SampleRate,
+		Resolution: a.config.SampleRate / float64(n),
+		Segments:   a.segments,
+		Discarded:  a.discarded,
+	}
+
+	for axis := 0; axis < 3; axis++ {
+		psd := make([]float64, n/2+1)
+		total := 0.0
+		for k := range psd {
+			psd[k] = a.sum[axis][k] / float64(a.segments) / (a.config.SampleRate * a.windowPower)
+			if k != 0 && k != n/2 {
+				// One-sided, the negative frequencies fold onto the positive ones.
+				psd[k] *= 2
+			}
+			total += psd[k] * s.Resolution
+		}
+		s.PSD[axis] = psd
+		s.RMS[axis] = math.Sqrt(total)
+		s.Peaks[axis] = findPeaks(psd, s.Resolution, a.config.MinFrequency, a.config.Peaks)
+		s.Bands[axis] = bandEnergies(psd, s.Resolution, a.config.Bands)
+	}
+	return s
+}
+
+// findPeaks returns the count highest local maxima of psd above minFrequency.
+// Their frequency is refined by fitting a parabola through the neighbor bins.
+func findPeaks(psd []float64, resolution, minFrequency float64, count int) []Peak {
+	var peaks []Peak
+	for k := 1; k < len(psd)-1; k++ {
+		if float64(k)*resolution < minFrequency || psd[k] <= psd[k-1] || psd[k] < psd[k+1] {
+			continue
+		}
+
+		previous, current, next := psd[k-1], psd[k], psd[k+1]
+		offset := 0.0
+		if curvature := previous - 2*current + next; curvature != 0 {
+			offset = 0.5 * (previous - next) / curvature
+		}
+		peaks = append(peaks, Peak{
+			Frequency: (float64(k) + offset) * resolution,
+			PSD:       current - 0.25*(previous-next)*offset,
+		})
+	}
+
+	sort.Slice(peaks, func(i, j int) bool { return peaks[i].PSD > peaks[j].PSD })
+	if len(peaks) > count {
+		peaks = peaks[:count]
+	}
+	return peaks
+}
+
+func bandEnergies(psd []float64, resolution float64, bands []Band) []BandEnergy {
+	nyquist := float64(len(psd)-1) * resolution
+	var energies []BandEnergy
+	for _, band := range bands {
+		if band.Low >= nyquist {
+			continue
+		}
+		energy := 0.0
+		for k, density := range psd {
+			if frequency := float64(k) * resolution; frequency >= band.Low && frequency < band.High {
+				energy += density * resolution
+			}
+		}
+		energies = append(energies, BandEnergy{Band: band, Energy: energy, RMS: math.Sqrt(energy)})
+	}
+	return energies
+}
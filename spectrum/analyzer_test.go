@@ -0,0 +1,147 @@
+package spectrum
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FFT(t *testing.T) {
+	x := make([]complex128, 16)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*3*float64(i)/16), 0)
+	}
+	fft(x)
+
+	for k, value := range x {
+		expected := 0.0
+		if k == 3 || k == 13 {
+			expected = 8
+		}
+		assert.InDelta(t, expected, cmplx.Abs(value), 1e-9, "bin %d", k)
+	}
+}
+
+func Test_Analyzer(t *testing.T) {
+	const sampleRate = 1000
+
+	analyzer, err := NewAnalyzer(DefaultConfig(sampleRate))
+	require.NoError(t, err)
+
+	random := rand.New(rand.NewSource(1))
+	var spectra []*Spectrum
+	for n := 0; n < 5000; n++ {
+		seconds := float64(n) / sampleRate
+		if spectrum := analyzer.Process(&iim42652.CameraSample{
+			Time: time.Unix(0, 0).Add(time.Duration(n) * time.Millisecond),
+			Acceleration: [3]float64{
+				0.2 * math.Sin(2*math.Pi*47.3*seconds),
+				0.002 * random.NormFloat64(),
+				1 + 0.05*math.Sin(2*math.Pi*120*seconds) + 0.02*math.Sin(2*math.Pi*8*seconds),
+			},
+		}); spectrum != nil {
+			spectra = append(spectra, spectrum)
+		}
+	}
+
+	// The first spectrum needs a full window plus 7 hops of 512 samples.
+	require.Len(t, spectra, 1)
+	spectrum := spectra[0]
+	assert.Equal(t, time.Unix(0, 0), spectrum.Start)
+	assert.Equal(t, time.Unix(0, 0).Add(4607*time.Millisecond), spectrum.End)
+	assert.Equal(t, 8, spectrum.Segments)
+	assert.InDelta(t, 0.9765625, spectrum.Resolution, 1e-9)
+	assert.Len(t, spectrum.PSD[0], 513)
+
+	assert.InDelta(t, 47.3, spectrum.Peaks[0][0].Frequency, 0.2)
+	assert.InDelta(t, 0.2/math.Sqrt2, spectrum.RMS[0], 0.002)
+
+	assert.InDelta(t, 120, spectrum.Peaks[2][0].Frequency, 0.2)
+	assert.InDelta(t, 8, spectrum.Peaks[2][1].Frequency, 0.2)
+	assert.InDelta(t, math.Sqrt(0.05*0.05/2+0.02*0.02/2), spectrum.RMS[2], 0.001)
+
+	// White noise spreads evenly, its PSD is σ²/(fs/2).
+	assert.InDelta(t, 0.002, spectrum.RMS[1], 0.0002)
+	assert.InDelta(t, 0.002*0.002/500, spectrum.PSD[1][200], 0.002*0.002/500)
+
+	expectedBands := []struct {
+		band Band
+		rms  [3]float64
+	}{
+		{Band{1, 5}, [3]float64{0, 0.0001, 0}},
+		{Band{5, 20}, [3]float64{0, 0.0003, 0.02 / math.Sqrt2}},
+		{Band{20, 50}, [3]float64{0.2 / math.Sqrt2, 0.0005, 0}},
+		{Band{50, 100}, [3]float64{0, 0.0006, 0}},
+		{Band{100, 200}, [3]float64{0, 0.0009, 0.05 / math.Sqrt2}},
+		{Band{200, 500}, [3]float64{0, 0.0015, 0}},
+	}
+	for axis := 0; axis < 3; axis++ {
+		require.Len(t, spectrum.Bands[axis], len(expectedBands))
+		for i, expected := range expectedBands {
+			assert.Equal(t, expected.band, spectrum.Bands[axis][i].Band)
+			assert.InDelta(t, expected.rms[axis], spectrum.Bands[axis][i].RMS, 0.0015, "axis %d band %s", axis, expected.band)
+		}
+	}
+}
+
+func Test_AnalyzerGap(t *testing.T) {
+	const sampleRate = 1000
+
+	analyzer, err := NewAnalyzer(DefaultConfig(sampleRate))
+	require.NoError(t, err)
+
+	var spectra []*Spectrum
+	at := time.Unix(0, 0)
+	for n := 0; n < 7000; n++ {
+		seconds := at.Sub(time.Unix(0, 0)).Seconds()
+		if spectrum := analyzer.Process(&iim42652.CameraSample{
+			Time:         at,
+			Acceleration: [3]float64{0.2 * math.Sin(2*math.Pi*47.3*seconds), 0, 1},
+		}); spectrum != nil {
+			spectra = append(spectra, spectrum)
+		}
+		at = at.Add(time.Millisecond)
+		if n == 2000 {
+			// Three sample periods skipped.
+			at = at.Add(3 * time.Millisecond)
+		}
+	}
+
+	// The 2 windows holding the gap are left out.
+	require.Len(t, spectra, 1)
+	spectrum := spectra[0]
+	assert.Equal(t, 8, spectrum.Segments)
+	assert.Equal(t, 2, spectrum.Discarded)
+	assert.Equal(t, time.Unix(0, 0), spectrum.Start)
+	assert.InDelta(t, 47.3, spectrum.Peaks[0][0].Frequency, 0.2)
+}
+
+func Test_ConfigValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(c *Config)
+	}{
+		{"sample rate", func(c *Config) { c.SampleRate = 0 }},
+		{"window not a power of two", func(c *Config) { c.WindowSize = 1000 }},
+		{"window too small", func(c *Config) { c.WindowSize = 8 }},
+		{"overlap", func(c *Config) { c.Overlap = 1 }},
+		{"averages", func(c *Config) { c.Averages = 0 }},
+		{"peaks", func(c *Config) { c.Peaks = -1 }},
+		{"band", func(c *Config) { c.Bands = []Band{{10, 5}} }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig(1000)
+			test.update(config)
+			_, err := NewAnalyzer(config)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package web
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/export"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+)
+
+//go:embed index.html
+var index []byte
+
+// ConfigurationReader is implemented by *iim42652.IIM42652, which reports the
+// configuration from before a running self-test rather than the test settings.
+type ConfigurationReader interface {
+	ReadConfiguration() (*iim42652.Configuration, error)
+}
+
+type Config struct {
+	// Only one sample out of Decimation is sent to the WebSocket clients,
+	// unless they ask otherwise with the decimation query parameter.
+	Decimation int
+	// A WebSocket client that does not accept a message within this time is
+	// disconnected.
+	WriteTimeout time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Decimation:   10,
+		WriteTimeout: 5 * time.Second,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.Decimation < 1 {
+		return fmt.Errorf("decimation must be at least 1, got %d", c.Decimation)
+	}
+	if c.WriteTimeout <= 0 {
+		return fmt.Errorf("write timeout must be positive, got %s", c.WriteTimeout)
+	}
+	return nil
+}
+
+// Server serves the live values of a hub over HTTP for field debugging:
+//
+//	GET /         a page plotting the live values
+//	GET /current  the last sample and the motion state
+//	GET /status   the device configuration, sample rate and error counters
+//	GET /ws       a WebSocket stream of decimated samples and events
+//
+// The /current sample is an export.Row, see the export package. The WebSocket
+// messages are imu.v1.LiveMessage messages encoded with imuv1.JSON, the
+// samples hold the camera frame values.
+type Server struct {
+	config   *Config
+	hub      *hub.Hub
+	device   ConfigurationReader
+	mux      *http.ServeMux
+	upgrader websocket.Upgrader
+	started  time.Time
+
+	clients        atomic.Int64
+	droppedSamples atomic.Uint64
+	droppedEvents  atomic.Uint64
+	writeErrors    atomic.Uint64
+}
+
+func NewServer(h *hub.Hub, device ConfigurationReader, config *Config) (*Server, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	s := &Server{
+		config:  config,
+		hub:     h,
+		device:  device,
+		mux:     http.NewServeMux(),
+		started: time.Now(),
+	}
+	s.mux.HandleFunc("/", s.serveIndex)
+	s.mux.HandleFunc("/current", s.serveCurrent)
+	s.mux.HandleFunc("/status", s.serveStatus)
+	s.mux.HandleFunc("/ws", s.serveWebSocket)
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves on addr until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %q: %w", addr, err)
+	}
+
+	server := &http.Server{Handler: s}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(index)
+}
+
+type Current struct {
+	Sample      *export.Row  `json:"sample"`
+	MotionState motion.State `json:"motion_state"`
+}
+
+func (s *Server) serveCurrent(w http.ResponseWriter, r *http.Request) {
+	sample := s.hub.Latest()
+	if sample == nil {
+		http.Error(w, "no sample read yet", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, &Current{
+		Sample:      export.NewRow(sample, s.hub.Transform()),
+		MotionState: s.hub.Stats().State,
+	})
+}
+
+type Status struct {
+	Configuration *iim42652.Configuration `json:"configuration"`
+	// Set when the configuration could not be read.
+	ConfigurationError string    `json:"configuration_error,omitempty"`
+	SamplePeriod       string    `json:"sample_period"`
+	Stats              hub.Stats `json:"stats"`
+	Uptime             string    `json:"uptime"`
+	WebSocketClients   int64     `json:"websocket_clients"`
+	// Messages WebSocket clients missed because they were too slow.
+	WebSocketDroppedSamples uint64 `json:"websocket_dropped_samples"`
+	WebSocketDroppedEvents  uint64 `json:"websocket_dropped_events"`
+	WebSocketWriteErrors    uint64 `json:"websocket_write_errors"`
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	status := &Status{
+		SamplePeriod:            s.hub.Period().String(),
+		Stats:                   s.hub.Stats(),
+		Uptime:                  time.Since(s.started).Round(time.Second).String(),
+		WebSocketClients:        s.clients.Load(),
+		WebSocketDroppedSamples: s.droppedSamples.Load(),
+		WebSocketDroppedEvents:  s.droppedEvents.Load(),
+		WebSocketWriteErrors:    s.writeErrors.Load(),
+	}
+	configuration, err := s.device.ReadConfiguration()
+	if err != nil {
+		status.ConfigurationError = err.Error()
+	}
+	status.Configuration = configuration
+	writeJSON(w, status)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	decimation := s.config.Decimation
+	if value := r.URL.Query().Get("decimation"); value != "" {
+		var err error
+		if decimation, err = strconv.Atoi(value); err != nil || decimation < 1 {
+			http.Error(w, fmt.Sprintf("invalid decimation %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied.
+		return
+	}
+	defer conn.Close()
+
+	subscription := s.hub.Subscribe()
+	s.clients.Add(1)
+	defer func() {
+		s.hub.Unsubscribe(subscription)
+		s.clients.Add(-1)
+		s.droppedSamples.Add(subscription.DroppedSamples())
+		s.droppedEvents.Add(subscription.DroppedEvents())
+	}()
+
+	// Reading handles the control messages and notices the client leaving.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(message *imuv1.LiveMessage) bool {
+		payload, err := imuv1.JSON.Marshal(message)
+		if err != nil {
+			s.writeErrors.Add(1)
+			return false
+		}
+		conn.SetWriteDeadline(time.Now().Add(s.config.WriteTimeout))
+		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+			s.writeErrors.Add(1)
+			return false
+		}
+		return true
+	}
+
+	// The channels are closed once the hub stops, the messages still
+	// buffered are sent first.
+	samples, detected := subscription.Samples(), subscription.Events()
+	transform := s.hub.Transform()
+	count := 0
+	for samples != nil || detected != nil {
+		var message *imuv1.LiveMessage
+		select {
+		case <-gone:
+			return
+		case sample, ok := <-samples:
+			if !ok {
+				samples = nil
+				continue
+			}
+			count++
+			if (count-1)%decimation != 0 {
+				continue
+			}
+			message = &imuv1.LiveMessage{Message: &imuv1.LiveMessage_Sample{Sample: imuv1.NewSample(sample, transform)}}
+		case event, ok := <-detected:
+			if !ok {
+				detected = nil
+				continue
+			}
+			message = &imuv1.LiveMessage{Message: &imuv1.LiveMessage_Event{Event: imuv1.NewEvent(event)}}
+		}
+		if !send(message) {
+			return
+		}
+	}
+	s.closeWebSocket(conn)
+}
+
+// closeWebSocket tells the client the device stopped.
+func (s *Server) closeWebSocket(conn *websocket.Conn) {
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "device stopped")
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(s.config.WriteTimeout))
+}
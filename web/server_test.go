@@ -0,0 +1,105 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/streamingfast/imu-controller/detector/motion"
+	"github.com/streamingfast/imu-controller/device/iim42652"
+	"github.com/streamingfast/imu-controller/hub"
+	imuv1 "github.com/streamingfast/imu-controller/pb/imu/v1"
+	"github.com/streamingfast/imu-controller/replay/replaytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var start = replaytest.Start
+
+func get(t *testing.T, server *httptest.Server, path string, value any) int {
+	response, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	if value != nil && response.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(response.Body).Decode(value))
+	}
+	return response.StatusCode
+}
+
+func Test_Server(t *testing.T) {
+	device := replaytest.NewGatedDevice(t)
+	transform, err := iim42652.DefaultAxisMap().Transform()
+	require.NoError(t, err)
+	h, err := hub.New(device, hub.DefaultConfig(transform))
+	require.NoError(t, err)
+	hubDone := make(chan error)
+	go func() { hubDone <- h.Run(context.Background()) }()
+
+	s, err := NewServer(h, device, &Config{Decimation: 10, WriteTimeout: time.Second})
+	require.NoError(t, err)
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	assert.Equal(t, http.StatusOK, get(t, server, "/", nil))
+	assert.Equal(t, http.StatusNotFound, get(t, server, "/missing", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, server, "/current", nil))
+	assert.Equal(t, http.StatusBadRequest, get(t, server, "/ws?decimation=0", nil))
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?decimation=25"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return h.Subscribers() == 1 }, time.Second, time.Millisecond)
+
+	status := &Status{}
+	require.Equal(t, http.StatusOK, get(t, server, "/status", status))
+	assert.Equal(t, 100.0, status.Configuration.AccelerometerODR)
+	assert.Equal(t, "10ms", status.SamplePeriod)
+	assert.Equal(t, int64(1), status.WebSocketClients)
+
+	device.Start()
+	var samples []time.Time
+	var detected []*imuv1.Event
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+			break
+		}
+		message := &imuv1.LiveMessage{}
+		require.NoError(t, protojson.Unmarshal(payload, message))
+		if sample := message.GetSample(); sample != nil {
+			samples = append(samples, sample.Time.AsTime())
+			assert.InDelta(t, 1.0, sample.CameraAcceleration.GetZ(), 0.001)
+		} else {
+			detected = append(detected, message.GetEvent())
+		}
+	}
+	assert.Equal(t, []time.Time{start, start.Add(250 * time.Millisecond), start.Add(500 * time.Millisecond), start.Add(750 * time.Millisecond), start.Add(time.Second)}, samples)
+	require.Len(t, detected, 2)
+	assert.NotNil(t, detected[0].GetMotionState())
+	assert.NotNil(t, detected[1].GetImpact())
+	require.NoError(t, <-hubDone)
+
+	current := &Current{}
+	require.Equal(t, http.StatusOK, get(t, server, "/current", current))
+	assert.Equal(t, start.Add(time.Second), current.Sample.Time)
+	assert.Equal(t, int16(100), current.Sample.RawAngularRateZ)
+	assert.InDelta(t, 1.0, current.Sample.CameraAccelerationZ, 0.001)
+	assert.Equal(t, motion.StateStationary, current.MotionState)
+
+	require.Eventually(t, func() bool {
+		status = &Status{}
+		get(t, server, "/status", status)
+		return status.WebSocketClients == 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, uint64(101), status.Stats.Samples)
+	assert.Equal(t, 100.0, status.Stats.SampleRate)
+	assert.Equal(t, uint64(0), status.WebSocketWriteErrors)
+}